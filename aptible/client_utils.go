@@ -91,3 +91,23 @@ func formatFloat32ToFloat64(val float32) float64 {
 	result, _ := strconv.ParseFloat(formatted, 64)
 	return result
 }
+
+// listAllPages returns the items of every page of a list endpoint. fetch gets
+// one page, starting at 1, and returns its items along with the response's
+// per_page and total_count. Pages are fetched until one is empty or the last
+// page has been reached.
+func listAllPages[T any](fetch func(page int32) (items []T, perPage int32, totalCount int32, err error)) ([]T, error) {
+	all := []T{}
+
+	for page := int32(1); ; page++ {
+		items, perPage, totalCount, err := fetch(page)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+
+		if len(items) == 0 || page*perPage >= totalCount {
+			return all, nil
+		}
+	}
+}
@@ -116,6 +116,85 @@ func TestGenerateErrorFromClientError(t *testing.T) {
 	}
 }
 
+func TestListAllPages(t *testing.T) {
+	tests := []struct {
+		name       string
+		pages      [][]int
+		perPage    int32
+		totalCount int32
+		want       []int
+		wantPages  int32
+	}{
+		{
+			name:       "single page",
+			pages:      [][]int{{1, 2}},
+			perPage:    10,
+			totalCount: 2,
+			want:       []int{1, 2},
+			wantPages:  1,
+		},
+		{
+			name:       "stops after the last page",
+			pages:      [][]int{{1, 2}, {3, 4}, {5}},
+			perPage:    2,
+			totalCount: 5,
+			want:       []int{1, 2, 3, 4, 5},
+			wantPages:  3,
+		},
+		{
+			name:       "full last page",
+			pages:      [][]int{{1, 2}, {3, 4}},
+			perPage:    2,
+			totalCount: 4,
+			want:       []int{1, 2, 3, 4},
+			wantPages:  2,
+		},
+		{
+			name:       "stops at an empty page",
+			pages:      [][]int{{1, 2}, {}},
+			perPage:    2,
+			totalCount: 10,
+			want:       []int{1, 2},
+			wantPages:  2,
+		},
+		{
+			name:       "no items",
+			pages:      [][]int{{}},
+			perPage:    10,
+			totalCount: 0,
+			want:       []int{},
+			wantPages:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetched := int32(0)
+			got, err := listAllPages(func(page int32) ([]int, int32, int32, error) {
+				fetched = page
+				return tt.pages[page-1], tt.perPage, tt.totalCount, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("listAllPages() = %v, want %v", got, tt.want)
+			}
+			if fetched != tt.wantPages {
+				t.Errorf("listAllPages() fetched %d pages, want %d", fetched, tt.wantPages)
+			}
+		})
+	}
+
+	t.Run("returns errors", func(t *testing.T) {
+		_, err := listAllPages(func(page int32) ([]int, int32, int32, error) {
+			return nil, 0, 0, errors.New("boom")
+		})
+		if err == nil || err.Error() != "boom" {
+			t.Errorf("listAllPages() error = %v, want boom", err)
+		}
+	})
+}
+
 func WithTestAccEnvironment(t *testing.T, f func(env aptible.Environment)) {
 	if os.Getenv("TF_ACC") != "1" {
 		return
@@ -0,0 +1,245 @@
+package aptible
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/aptible/go-deploy/aptible"
+	deploy "github.com/aptible/go-deploy/client"
+	"github.com/go-openapi/runtime"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+const (
+	defaultAPIRootURL  = "https://api.aptible.com"
+	defaultAuthRootURL = "https://auth.aptible.com"
+)
+
+// providerConfig holds the values from a single `provider "aptible"` block.
+// Every configured instance (including aliases) gets its own providerConfig,
+// so multiple organizations can be managed from the same root module.
+type providerConfig struct {
+	AccessToken string
+	APIRootURL  string
+	AuthRootURL string
+	Username    string
+	Password    string
+}
+
+// metadata builds the clients used by resources for this provider instance.
+func (c *providerConfig) metadata() (*providerMetadata, error) {
+	token, err := c.token()
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, fmt.Errorf("could not read token, please run aptible login or set APTIBLE_ACCESS_TOKEN")
+	}
+
+	tokens := newTokenSource(c, token)
+	httpClient := &http.Client{
+		Transport: &refreshingTransport{base: http.DefaultTransport, tokens: tokens},
+	}
+
+	legacy, err := newLegacyClient(c.APIRootURL, tokens, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &providerMetadata{
+		LegacyClient: legacy,
+		Client:       newAPIClient(c.APIRootURL, httpClient),
+		tokens:       tokens,
+		authRootURL:  c.AuthRootURL,
+		httpClient:   httpClient,
+	}, nil
+}
+
+// token resolves an access token using the same precedence as the Aptible CLI:
+// username/password, then an explicit access token, then the token stored by
+// `aptible login` for the configured auth server.
+func (c *providerConfig) token() (string, error) {
+	if c.Username != "" && c.Password != "" {
+		return loginWithUsernameAndPassword(c.AuthRootURL, c.Username, c.Password)
+	}
+
+	if c.AccessToken != "" {
+		return c.AccessToken, nil
+	}
+
+	return readTokenFromFile(c.AuthRootURL)
+}
+
+// loginWithUsernameAndPassword requests a token from the auth server
+func loginWithUsernameAndPassword(authURL, username, password string) (string, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"expires":    43200,
+		"username":   username,
+		"password":   password,
+		"grant_type": "password",
+		"scope":      "manage",
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to encode username and password to login: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimRight(authURL, "/")+"/tokens", bytes.NewBuffer(payload))
+	if err != nil {
+		return "", fmt.Errorf("unable to construct login request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to login with username and password: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("unable to read login response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("unable to login with username and password: %s - %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var out struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return "", fmt.Errorf("unable to decode login response: %w", err)
+	}
+	if out.AccessToken == "" {
+		return "", fmt.Errorf("token not found in payload when logging in with username and password")
+	}
+
+	return out.AccessToken, nil
+}
+
+// getOrganization returns the only organization the token has access to, from
+// the provider instance's auth server. It errors when there are none or more
+// than one, as the organization can't be inferred then.
+func getOrganization(ctx context.Context, m *providerMetadata) (aptible.Organization, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(m.authRootURL, "/")+"/organizations", nil)
+	if err != nil {
+		return aptible.Organization{}, fmt.Errorf("unable to construct organizations request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+m.tokens.Token())
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return aptible.Organization{}, fmt.Errorf("unable to list organizations: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return aptible.Organization{}, fmt.Errorf("unable to list organizations, check credentials: %s", resp.Status)
+	}
+
+	var out aptible.HALOrganizationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return aptible.Organization{}, fmt.Errorf("unable to decode organizations response: %w", err)
+	}
+
+	organizations := out.Embedded.Organizations
+	switch len(organizations) {
+	case 0:
+		return aptible.Organization{}, fmt.Errorf("no organizations found in response")
+	case 1:
+		return organizations[0], nil
+	default:
+		found := make([]string, len(organizations))
+		for i, org := range organizations {
+			found[i] = fmt.Sprintf("%s (org_id: %s)", org.Name, org.ID)
+		}
+		return aptible.Organization{}, fmt.Errorf("multiple organizations for user, unable to determine"+
+			" a default organization in result. Organizations found: %s", strings.Join(found, ", "))
+	}
+}
+
+// readTokenFromFile reads the token written by `aptible login` for authURL
+func readTokenFromFile(authURL string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not find a token, please run aptible login or set APTIBLE_ACCESS_TOKEN: %w", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(home, ".aptible", "tokens.json"))
+	if err != nil {
+		return "", fmt.Errorf("could not find a token, please run aptible login or set APTIBLE_ACCESS_TOKEN: %w", err)
+	}
+
+	var tokens map[string]string
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return "", fmt.Errorf("could not read ~/.aptible/tokens.json: %w", err)
+	}
+
+	if token := tokens[authURL]; token != "" {
+		return token, nil
+	}
+
+	return "", fmt.Errorf("no token found for %s, please run aptible login or set APTIBLE_ACCESS_TOKEN", authURL)
+}
+
+// newLegacyClient configures a go-deploy client for apiRootURL. This mirrors
+// aptible.SetUpClient without reading any values from the environment, and
+// reads the token from tokens on every request so refreshed tokens are used.
+func newLegacyClient(apiRootURL string, tokens *tokenSource, httpClient *http.Client) (*aptible.Client, error) {
+	u, err := url.Parse(apiRootURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid api_root_url %q: %w", apiRootURL, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid api_root_url %q: missing host", apiRootURL)
+	}
+
+	schemes := deploy.DefaultSchemes
+	if u.Scheme != "" {
+		schemes = []string{u.Scheme}
+	}
+	basePath := deploy.DefaultBasePath
+	if u.Path != "" {
+		basePath = u.Path
+	}
+
+	rt := httptransport.NewWithClient(u.Host, basePath, schemes, httpClient)
+	rt.Consumers["application/hal+json"] = runtime.JSONConsumer()
+	rt.Producers["application/hal+json"] = runtime.JSONProducer()
+
+	return &aptible.Client{
+		Client: deploy.New(rt, strfmt.Default),
+		Token: runtime.ClientAuthInfoWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+			return r.SetHeaderParam("Authorization", "Bearer "+tokens.Token())
+		}),
+		// RawToken is left empty: it would be captured once and go stale after a
+		// refresh. The only go-deploy call using it, GetOrganization, is replaced
+		// by getOrganization.
+	}, nil
+}
+
+// newAPIClient configures an aptible-api-go client for apiRootURL. The token is
+// supplied per request by providerMetadata.APIContext.
+func newAPIClient(apiRootURL string, httpClient *http.Client) *aptibleapi.APIClient {
+	cfg := aptibleapi.NewAPIConfiguration()
+	cfg.HTTPClient = httpClient
+	cfg.Servers = aptibleapi.ServerConfigurations{
+		{URL: strings.TrimRight(apiRootURL, "/")},
+	}
+	return aptibleapi.NewAPIClient(cfg)
+}
@@ -0,0 +1,193 @@
+package aptible
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProviderConfigToken(t *testing.T) {
+	auth := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tokens" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["username"] != "robot@example.com" || body["password"] != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_credentials"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"from-login"}`))
+	}))
+	defer auth.Close()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".aptible"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	tokens, _ := json.Marshal(map[string]string{auth.URL: "from-file"})
+	if err := os.WriteFile(filepath.Join(home, ".aptible", "tokens.json"), tokens, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  providerConfig
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "prefers username and password",
+			config: providerConfig{AuthRootURL: auth.URL, Username: "robot@example.com", Password: "secret", AccessToken: "explicit"},
+			want:   "from-login",
+		},
+		{
+			name:    "surfaces login failures",
+			config:  providerConfig{AuthRootURL: auth.URL, Username: "robot@example.com", Password: "wrong"},
+			wantErr: true,
+		},
+		{
+			name:   "uses the access token",
+			config: providerConfig{AuthRootURL: auth.URL, AccessToken: "explicit"},
+			want:   "explicit",
+		},
+		{
+			name:   "falls back to tokens.json",
+			config: providerConfig{AuthRootURL: auth.URL},
+			want:   "from-file",
+		},
+		{
+			name:    "errors when tokens.json has no token for the auth server",
+			config:  providerConfig{AuthRootURL: "https://auth.example.com"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.token()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("token() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("token() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewLegacyClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "accepts an https url", url: "https://api.aptible.com"},
+		{name: "accepts an http url with a port", url: "http://localhost:3000"},
+		{name: "rejects a url without a host", url: "api.aptible.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newLegacyClient(tt.url, newTokenSource(&providerConfig{}, "token"), http.DefaultClient)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newLegacyClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewLegacyClientReadsTokenPerRequest(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	tokens := newTokenSource(&providerConfig{}, "first")
+	client, err := newLegacyClient(server.URL, tokens, server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _ = client.GetEnvironment(1)
+	tokens.token = "refreshed"
+	_, _ = client.GetEnvironment(1)
+
+	want := []string{"Bearer first", "Bearer refreshed"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("Authorization headers = %v, want %v", got, want)
+	}
+}
+
+func TestGetOrganization(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		organizations string
+		want          string
+		wantErr       string
+	}{
+		{
+			name:          "returns the only organization",
+			status:        http.StatusOK,
+			organizations: `[{"id": "org-1", "name": "Example"}]`,
+			want:          "org-1",
+		},
+		{
+			name:          "errors with several organizations",
+			status:        http.StatusOK,
+			organizations: `[{"id": "org-1", "name": "Example"}, {"id": "org-2", "name": "Other"}]`,
+			wantErr:       "Organizations found: Example (org_id: org-1), Other (org_id: org-2)",
+		},
+		{
+			name:          "errors without organizations",
+			status:        http.StatusOK,
+			organizations: `[]`,
+			wantErr:       "no organizations found",
+		},
+		{
+			name:    "errors when the auth server rejects the token",
+			status:  http.StatusUnauthorized,
+			wantErr: "401 Unauthorized",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/organizations" || r.Header.Get("Authorization") != "Bearer token" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"_embedded": {"organizations": ` + tt.organizations + `}}`))
+			}))
+			defer auth.Close()
+
+			got, err := getOrganization(context.Background(), testProviderMetadata(auth))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("getOrganization() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.ID != tt.want {
+				t.Fatalf("getOrganization() = %q, want %q", got.ID, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package aptible
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceApp() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAppRead,
+		Schema: map[string]*schema.Schema{
+			"env_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"handle": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"app_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"git_repo": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"docker_image": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"config_keys": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The names of the app's configuration variables. Values are not exposed.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"service": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"process_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"container_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"container_memory_limit": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"container_profile": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAppRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	client := m.Client
+	ctx = m.APIContext(ctx)
+	envID := int32(d.Get("env_id").(int))
+	handle := d.Get("handle").(string)
+
+	log.Printf("Getting App with handle: %s in environment with ID: %d\n", handle, envID)
+
+	apps, err := listAppsForAccount(ctx, meta, envID)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error fetching apps for environment with ID: %d", envID),
+			Detail:   err.Error(),
+		}}
+	}
+
+	var appID int32
+	for _, a := range apps {
+		if a.Handle == handle {
+			appID = a.Id
+			break
+		}
+	}
+	if appID == 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("App with handle %q not found", handle),
+			Detail:   fmt.Sprintf("There is no app with handle %q in the environment with ID: %d", handle, envID),
+		}}
+	}
+
+	// The list doesn't embed services, so fetch the app itself
+	app, _, err := client.AppsAPI.GetApp(ctx, appID).Execute()
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error fetching app with ID: %d", appID),
+			Detail:   err.Error(),
+		}}
+	}
+
+	configKeys := []string{}
+	if currConfID := ExtractIdFromLink(app.Links.CurrentConfiguration.GetHref()); currConfID != 0 {
+		currConf, _, err := client.ConfigurationsAPI.GetConfiguration(ctx, currConfID).Execute()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for k := range currConf.Env {
+			configKeys = append(configKeys, k)
+		}
+		sort.Strings(configKeys)
+	}
+
+	dockerImage := ""
+	if currSettingID := ExtractIdFromLink(app.Links.CurrentSetting.GetHref()); currSettingID != 0 {
+		currSetting, _, err := client.SettingsAPI.GetSetting(ctx, currSettingID).Execute()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		dockerImage, _ = currSetting.Settings["APTIBLE_DOCKER_IMAGE"].(string)
+	}
+
+	services := make([]map[string]interface{}, len(app.Embedded.Services))
+	for i, s := range app.Embedded.Services {
+		service := map[string]interface{}{
+			"process_type":      s.ProcessType,
+			"container_count":   s.ContainerCount,
+			"container_profile": normalizeContainerProfile(s.InstanceClass),
+		}
+		if s.ContainerMemoryLimitMb.IsSet() && s.ContainerMemoryLimitMb.Get() != nil {
+			service["container_memory_limit"] = *s.ContainerMemoryLimitMb.Get()
+		}
+		services[i] = service
+	}
+
+	d.SetId(strconv.Itoa(int(app.Id)))
+	_ = d.Set("app_id", int(app.Id))
+	_ = d.Set("git_repo", app.GitRepo)
+	_ = d.Set("docker_image", dockerImage)
+	_ = d.Set("config_keys", configKeys)
+	_ = d.Set("service", services)
+
+	return nil
+}
+
+// listAppsForAccount returns every app in the environment, following
+// pagination
+func listAppsForAccount(ctx context.Context, meta interface{}, envID int32) ([]aptibleapi.App, error) {
+	client := meta.(*providerMetadata).Client
+	return listAllPages(func(page int32) ([]aptibleapi.App, int32, int32, error) {
+		resp, _, err := client.AppsAPI.ListAppsForAccount(ctx, envID).Page(page).Execute()
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		return resp.Embedded.Apps, resp.PerPage, resp.TotalCount, nil
+	})
+}
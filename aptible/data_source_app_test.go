@@ -0,0 +1,68 @@
+package aptible
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceApp_validation(t *testing.T) {
+	requiredAttrs := []string{"env_id", "handle"}
+	var testSteps []resource.TestStep
+
+	for _, attr := range requiredAttrs {
+		testSteps = append(testSteps, resource.TestStep{
+			PlanOnly:    true,
+			Config:      `data "aptible_app" "test" {}`,
+			ExpectError: regexp.MustCompile(fmt.Sprintf("%q is required", attr)),
+		})
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps:             testSteps,
+	})
+}
+
+func TestAccDataSourceApp_basic(t *testing.T) {
+	rHandle := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAppDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAptibleAppDataSource(rHandle),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("aptible_app.test", "app_id", "data.aptible_app.test", "app_id"),
+					resource.TestCheckResourceAttrPair("aptible_app.test", "git_repo", "data.aptible_app.test", "git_repo"),
+					resource.TestCheckResourceAttr("data.aptible_app.test", "docker_image", "quay.io/aptible/nginx-mirror:1"),
+					resource.TestCheckResourceAttr("data.aptible_app.test", "config_keys.#", "2"),
+					resource.TestCheckResourceAttr("data.aptible_app.test", "config_keys.0", "OOPS"),
+					resource.TestCheckResourceAttr("data.aptible_app.test", "config_keys.1", "WHATEVER"),
+					resource.TestCheckResourceAttr("data.aptible_app.test", "service.#", "1"),
+					resource.TestCheckResourceAttr("data.aptible_app.test", "service.0.process_type", "cmd"),
+					resource.TestCheckResourceAttr("data.aptible_app.test", "service.0.container_count", "1"),
+					resource.TestCheckResourceAttr("data.aptible_app.test", "service.0.container_memory_limit", "512"),
+					resource.TestCheckResourceAttr("data.aptible_app.test", "service.0.container_profile", "m"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAptibleAppDataSource(handle string) string {
+	return fmt.Sprintf(`
+%s
+
+	data "aptible_app" "test" {
+		env_id = aptible_app.test.env_id
+		handle = aptible_app.test.handle
+	}
+`, testAccAptibleAppDeploy(handle, "1"))
+}
@@ -0,0 +1,102 @@
+package aptible
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceApps() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAppsRead,
+		Schema: map[string]*schema.Schema{
+			"env_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"handle_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"apps": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"app_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"env_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"handle": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAppsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	ctx = m.APIContext(ctx)
+	envID := int32(d.Get("env_id").(int))
+	handleRegex := regexp.MustCompile(d.Get("handle_regex").(string))
+
+	log.Printf("Listing Apps in environment with ID: %d\n", envID)
+
+	var apps []aptibleapi.App
+	var err error
+	if envID != 0 {
+		apps, err = listAppsForAccount(ctx, meta, envID)
+	} else {
+		apps, err = listApps(ctx, meta)
+	}
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Error listing apps",
+			Detail:   err.Error(),
+		}}
+	}
+
+	results := []map[string]interface{}{}
+	for _, a := range apps {
+		if !handleRegex.MatchString(a.Handle) {
+			continue
+		}
+		results = append(results, map[string]interface{}{
+			"app_id": int(a.Id),
+			"env_id": int(ExtractIdFromLink(a.GetLinks().Account.GetHref())),
+			"handle": a.Handle,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%d/%s", envID, handleRegex))
+	_ = d.Set("apps", results)
+	return nil
+}
+
+// listApps returns every app the user can access, following pagination
+func listApps(ctx context.Context, meta interface{}) ([]aptibleapi.App, error) {
+	client := meta.(*providerMetadata).Client
+	return listAllPages(func(page int32) ([]aptibleapi.App, int32, int32, error) {
+		resp, _, err := client.AppsAPI.ListApps(ctx).Page(page).Execute()
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		return resp.Embedded.Apps, resp.PerPage, resp.TotalCount, nil
+	})
+}
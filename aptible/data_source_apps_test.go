@@ -0,0 +1,65 @@
+package aptible
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceApps_validation(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PlanOnly: true,
+				Config: `
+	data "aptible_apps" "test" {
+		handle_regex = "["
+	}
+`,
+				ExpectError: regexp.MustCompile(`"handle_regex": error parsing regexp`),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceApps_basic(t *testing.T) {
+	rHandle := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAppDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAptibleAppsDataSource(rHandle),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aptible_apps.all", "apps.#", "1"),
+					resource.TestCheckResourceAttrPair("aptible_app.test", "app_id", "data.aptible_apps.all", "apps.0.app_id"),
+					resource.TestCheckResourceAttrPair("aptible_app.test", "env_id", "data.aptible_apps.all", "apps.0.env_id"),
+					resource.TestCheckResourceAttr("data.aptible_apps.all", "apps.0.handle", rHandle),
+					resource.TestCheckResourceAttr("data.aptible_apps.none", "apps.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAptibleAppsDataSource(handle string) string {
+	return fmt.Sprintf(`
+%s
+
+	data "aptible_apps" "all" {
+		env_id = aptible_app.test.env_id
+	}
+
+	data "aptible_apps" "none" {
+		env_id = aptible_app.test.env_id
+		handle_regex = "^not-${aptible_app.test.handle}$"
+	}
+`, testAccAptibleAppDeploy(handle, "1"))
+}
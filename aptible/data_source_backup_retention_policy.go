@@ -42,6 +42,14 @@ func dataSourceBackupRetentionPolicy() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"pitr_days": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"copy_destination_region": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -91,6 +99,9 @@ func dataSourceBackupRetentionPolicyRead(ctx context.Context, d *schema.Resource
 	_ = d.Set("yearly", int(policy.Yearly))
 	_ = d.Set("make_copy", policy.MakeCopy)
 	_ = d.Set("keep_final", policy.KeepFinal)
+	_ = d.Set("pitr_days", int(policy.PitrDays))
+	region, _ := policy.AdditionalProperties["destination_region"].(string)
+	_ = d.Set("copy_destination_region", region)
 
 	return nil
 }
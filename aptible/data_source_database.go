@@ -0,0 +1,148 @@
+package aptible
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDatabase() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDatabaseRead,
+		Schema: map[string]*schema.Schema{
+			"env_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"handle": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"database_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"database_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"database_image_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"container_size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"container_profile": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"disk_size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"iops": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"enable_backups": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"default_connection_url": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"connection_urls": {
+				Type:      schema.TypeList,
+				Computed:  true,
+				Sensitive: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"parameters": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"credentials": databaseCredentialsSchema(),
+		},
+	}
+}
+
+func dataSourceDatabaseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	ctx = m.APIContext(ctx)
+	envID := int32(d.Get("env_id").(int))
+	handle := d.Get("handle").(string)
+
+	log.Printf("Getting Database with handle: %s in environment with ID: %d\n", handle, envID)
+
+	databases, err := listDatabasesForAccount(ctx, meta, envID)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error fetching databases for environment with ID: %d", envID),
+			Detail:   err.Error(),
+		}}
+	}
+
+	var databaseID int32
+	for _, db := range databases {
+		if db.Handle == handle {
+			databaseID = db.Id
+			break
+		}
+	}
+	if databaseID == 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Database with handle %q not found", handle),
+			Detail:   fmt.Sprintf("There is no database with handle %q in the environment with ID: %d", handle, envID),
+		}}
+	}
+
+	// The resource's read fetches the image and service and sets the same
+	// attributes this data source exposes
+	d.SetId(strconv.Itoa(int(databaseID)))
+	_ = d.Set("database_id", int(databaseID))
+	if err := resourceDatabaseRead(d, meta); err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error fetching database with ID: %d", databaseID),
+			Detail:   err.Error(),
+		}}
+	}
+	if d.Id() == "" {
+		return diag.Errorf("Database with handle %q was deleted while it was being read", handle)
+	}
+
+	return nil
+}
+
+// listDatabasesForAccount returns every database in the environment, following
+// pagination
+func listDatabasesForAccount(ctx context.Context, meta interface{}, envID int32) ([]aptibleapi.Database, error) {
+	client := meta.(*providerMetadata).Client
+	return listAllPages(func(page int32) ([]aptibleapi.Database, int32, int32, error) {
+		resp, _, err := client.DatabasesAPI.ListDatabasesForAccount(ctx, envID).Page(page).Execute()
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		return resp.Embedded.Databases, resp.PerPage, resp.TotalCount, nil
+	})
+}
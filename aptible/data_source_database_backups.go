@@ -0,0 +1,139 @@
+package aptible
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDatabaseBackups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDatabaseBackupsRead,
+		Schema: map[string]*schema.Schema{
+			"database_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ExactlyOneOf: []string{"database_id", "env_id"},
+			},
+			"env_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"backups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"backup_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"database_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"database_handle": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"copied_from": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDatabaseBackupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	ctx = m.APIContext(ctx)
+	databaseID := int32(d.Get("database_id").(int))
+	envID := int32(d.Get("env_id").(int))
+
+	log.Printf("Listing Backups for database with ID: %d, environment with ID: %d\n", databaseID, envID)
+
+	backups, err := listBackups(ctx, meta, databaseID, envID)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Error listing backups",
+			Detail:   err.Error(),
+		}}
+	}
+
+	results := make([]map[string]interface{}, len(backups))
+	for i, b := range backups {
+		links := b.GetLinks()
+		results[i] = map[string]interface{}{
+			"backup_id":       int(b.Id),
+			"database_id":     int(ExtractIdFromLink(links.Database.GetHref())),
+			"database_handle": b.DatabaseHandle,
+			"type":            backupType(b),
+			"size":            int(b.GetSize()),
+			"created_at":      b.CreatedAt,
+			"copied_from":     int(ExtractIdFromLink(links.CopiedFrom.GetHref())),
+			"region":          b.AwsRegion,
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%d/%d", databaseID, envID))
+	_ = d.Set("backups", results)
+	return nil
+}
+
+// backupType describes why the backup was taken. Final backups are taken when
+// a database is deprovisioned and are the only automatic backups to outlive
+// it, so they no longer link to a database.
+func backupType(b aptibleapi.Backup) string {
+	links := b.GetLinks()
+	switch {
+	case b.GetManual():
+		return "manual"
+	case links.Database.GetHref() == "":
+		return "final"
+	default:
+		return "automatic"
+	}
+}
+
+// listBackups returns every backup of the database, or of every database in
+// the environment when databaseID is 0, following pagination
+func listBackups(ctx context.Context, meta interface{}, databaseID int32, envID int32) ([]aptibleapi.Backup, error) {
+	client := meta.(*providerMetadata).Client
+	return listAllPages(func(page int32) ([]aptibleapi.Backup, int32, int32, error) {
+		var resp *aptibleapi.ListBackups200Response
+		var err error
+		if databaseID != 0 {
+			resp, _, err = client.BackupsAPI.ListBackupsForDatabase(ctx, databaseID).Page(page).Execute()
+		} else {
+			resp, _, err = client.BackupsAPI.ListBackupsForAccount(ctx, envID).Page(page).Execute()
+		}
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		return resp.Embedded.Backups, resp.PerPage, resp.TotalCount, nil
+	})
+}
@@ -0,0 +1,94 @@
+package aptible
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/aptible/go-deploy/aptible"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestBackupType(t *testing.T) {
+	manual := true
+	automatic := false
+	database := &aptibleapi.BackupLinks{
+		Database: &aptibleapi.ListAccountsForStack200ResponseLinksStack{Href: aptibleapi.PtrString("https://api.aptible.com/databases/7")},
+	}
+	deprovisioned := &aptibleapi.BackupLinks{}
+
+	tests := []struct {
+		name   string
+		backup aptibleapi.Backup
+		want   string
+	}{
+		{name: "manual", backup: aptibleapi.Backup{Manual: *aptibleapi.NewNullableBool(&manual), Links: database}, want: "manual"},
+		{name: "automatic", backup: aptibleapi.Backup{Manual: *aptibleapi.NewNullableBool(&automatic), Links: database}, want: "automatic"},
+		{name: "unknown is automatic", backup: aptibleapi.Backup{Links: database}, want: "automatic"},
+		{name: "final", backup: aptibleapi.Backup{Manual: *aptibleapi.NewNullableBool(&automatic), Links: deprovisioned}, want: "final"},
+		{name: "manual backups of deprovisioned databases stay manual", backup: aptibleapi.Backup{Manual: *aptibleapi.NewNullableBool(&manual), Links: deprovisioned}, want: "manual"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := backupType(tt.backup); got != tt.want {
+				t.Errorf("backupType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccDataSourceDatabaseBackups_validation(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PlanOnly:    true,
+				Config:      `data "aptible_database_backups" "test" {}`,
+				ExpectError: regexp.MustCompile("one of `database_id,env_id` must be specified"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceDatabaseBackups_basic(t *testing.T) {
+	dbHandle := acctest.RandString(10)
+
+	WithTestAccEnvironment(t, func(env aptible.Environment) {
+		resource.ParallelTest(t, resource.TestCase{
+			PreCheck:          func() { testAccPreCheck(t) },
+			ProviderFactories: testAccProviderFactories,
+			CheckDestroy:      testAccCheckDatabaseBackupDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccAptibleDatabaseBackupsDataSource(env.ID, dbHandle),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckTypeSetElemNestedAttrs("data.aptible_database_backups.database", "backups.*", map[string]string{
+							"type":            "manual",
+							"database_handle": dbHandle,
+						}),
+						resource.TestCheckTypeSetElemAttrPair("data.aptible_database_backups.database", "backups.*.backup_id", "aptible_database_backup.test", "backup_id"),
+						resource.TestCheckTypeSetElemAttrPair("data.aptible_database_backups.env", "backups.*.backup_id", "aptible_database_backup.test", "backup_id"),
+					),
+				},
+			},
+		})
+	})
+}
+
+func testAccAptibleDatabaseBackupsDataSource(envId int64, dbHandle string) string {
+	return fmt.Sprintf(`
+%s
+
+	data "aptible_database_backups" "database" {
+		database_id = aptible_database_backup.test.database_id
+	}
+
+	data "aptible_database_backups" "env" {
+		env_id = aptible_database_backup.test.env_id
+	}
+`, testAccAptibleDatabaseBackup(envId, dbHandle))
+}
@@ -0,0 +1,122 @@
+package aptible
+
+import (
+	"context"
+	"log"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceDatabaseImages() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDatabaseImagesRead,
+		Schema: map[string]*schema.Schema{
+			"database_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(validDBTypes, false),
+			},
+			"include_deprecated": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"images": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"database_image_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"database_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"default": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"deprecated": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDatabaseImagesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	ctx = m.APIContext(ctx)
+	databaseType := d.Get("database_type").(string)
+	includeDeprecated := d.Get("include_deprecated").(bool)
+
+	log.Printf("Listing Database Images with type: %q\n", databaseType)
+
+	images, err := listDatabaseImages(ctx, meta)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Error listing database images",
+			Detail:   err.Error(),
+		}}
+	}
+
+	results := []map[string]interface{}{}
+	for _, img := range images {
+		// Images that are no longer offered for new databases aren't
+		// discoverable, but existing databases may still run them
+		deprecated := !img.Discoverable
+		if !img.Visible {
+			continue
+		}
+		if databaseType != "" && img.Type != databaseType {
+			continue
+		}
+		if deprecated && !includeDeprecated {
+			continue
+		}
+		results = append(results, map[string]interface{}{
+			"database_image_id": int(img.Id),
+			"database_type":     img.Type,
+			"version":           img.Version,
+			"description":       img.Description,
+			"default":           img.Default,
+			"deprecated":        deprecated,
+		})
+	}
+
+	d.SetId(databaseType)
+	if databaseType == "" {
+		d.SetId("all")
+	}
+	_ = d.Set("images", results)
+	return nil
+}
+
+// listDatabaseImages returns every database image, following pagination
+func listDatabaseImages(ctx context.Context, meta interface{}) ([]aptibleapi.DatabaseImage, error) {
+	client := meta.(*providerMetadata).Client
+	return listAllPages(func(page int32) ([]aptibleapi.DatabaseImage, int32, int32, error) {
+		resp, _, err := client.ImagesAPI.ListDatabaseImages(ctx).Page(page).Execute()
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		return resp.Embedded.DatabaseImages, resp.PerPage, resp.TotalCount, nil
+	})
+}
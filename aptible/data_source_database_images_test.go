@@ -0,0 +1,52 @@
+package aptible
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDatabaseImages_validation(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PlanOnly: true,
+				Config: `
+	data "aptible_database_images" "test" {
+		database_type = "notadb"
+	}
+`,
+				ExpectError: regexp.MustCompile(`expected database_type to be one of`),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceDatabaseImages_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+	data "aptible_database_images" "test" {
+		database_type = "postgresql"
+		include_deprecated = false
+	}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.aptible_database_images.test", "images.0.database_image_id"),
+					resource.TestCheckResourceAttrSet("data.aptible_database_images.test", "images.0.version"),
+					resource.TestCheckResourceAttr("data.aptible_database_images.test", "images.0.database_type", "postgresql"),
+					resource.TestCheckResourceAttr("data.aptible_database_images.test", "images.0.deprecated", "false"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.aptible_database_images.test", "images.*", map[string]string{
+						"default": "true",
+					}),
+				),
+			},
+		},
+	})
+}
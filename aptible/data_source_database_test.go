@@ -0,0 +1,69 @@
+package aptible
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aptible/go-deploy/aptible"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDatabase_validation(t *testing.T) {
+	requiredAttrs := []string{"env_id", "handle"}
+	var testSteps []resource.TestStep
+
+	for _, attr := range requiredAttrs {
+		testSteps = append(testSteps, resource.TestStep{
+			PlanOnly:    true,
+			Config:      `data "aptible_database" "test" {}`,
+			ExpectError: regexp.MustCompile(fmt.Sprintf("%q is required", attr)),
+		})
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps:             testSteps,
+	})
+}
+
+func TestAccDataSourceDatabase_basic(t *testing.T) {
+	dbHandle := acctest.RandString(10)
+
+	WithTestAccEnvironment(t, func(env aptible.Environment) {
+		resource.ParallelTest(t, resource.TestCase{
+			PreCheck:          func() { testAccPreCheck(t) },
+			ProviderFactories: testAccProviderFactories,
+			CheckDestroy:      testAccCheckDatabaseDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccAptibleDatabaseDataSource(env.ID, dbHandle),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrPair("aptible_database.test", "database_id", "data.aptible_database.test", "database_id"),
+						resource.TestCheckResourceAttrPair("aptible_database.test", "database_image_id", "data.aptible_database.test", "database_image_id"),
+						resource.TestCheckResourceAttrPair("aptible_database.test", "default_connection_url", "data.aptible_database.test", "default_connection_url"),
+						resource.TestCheckResourceAttr("data.aptible_database.test", "database_type", "postgresql"),
+						resource.TestCheckResourceAttrSet("data.aptible_database.test", "version"),
+						resource.TestCheckResourceAttr("data.aptible_database.test", "container_size", "1024"),
+						resource.TestCheckResourceAttr("data.aptible_database.test", "disk_size", "10"),
+						resource.TestCheckResourceAttr("data.aptible_database.test", "iops", "3000"),
+						resource.TestCheckResourceAttr("data.aptible_database.test", "connection_urls.#", "1"),
+					),
+				},
+			},
+		})
+	})
+}
+
+func testAccAptibleDatabaseDataSource(envId int64, dbHandle string) string {
+	return fmt.Sprintf(`
+%s
+
+	data "aptible_database" "test" {
+		env_id = aptible_database.test.env_id
+		handle = aptible_database.test.handle
+	}
+`, testAccAptibleDatabaseBasic(envId, dbHandle))
+}
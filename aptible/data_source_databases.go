@@ -0,0 +1,117 @@
+package aptible
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceDatabases() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDatabasesRead,
+		Schema: map[string]*schema.Schema{
+			"env_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"handle_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"database_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(validDBTypes, false),
+			},
+			"databases": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"database_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"env_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"handle": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"database_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDatabasesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	ctx = m.APIContext(ctx)
+	envID := int32(d.Get("env_id").(int))
+	databaseType := d.Get("database_type").(string)
+	handleRegex := regexp.MustCompile(d.Get("handle_regex").(string))
+
+	log.Printf("Listing Databases in environment with ID: %d\n", envID)
+
+	var databases []aptibleapi.Database
+	var err error
+	if envID != 0 {
+		databases, err = listDatabasesForAccount(ctx, meta, envID)
+	} else {
+		databases, err = listDatabases(ctx, meta)
+	}
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Error listing databases",
+			Detail:   err.Error(),
+		}}
+	}
+
+	results := []map[string]interface{}{}
+	for _, db := range databases {
+		if !handleRegex.MatchString(db.Handle) {
+			continue
+		}
+		if databaseType != "" && db.GetType() != databaseType {
+			continue
+		}
+		results = append(results, map[string]interface{}{
+			"database_id":   int(db.Id),
+			"env_id":        int(ExtractIdFromLink(db.GetLinks().Account.GetHref())),
+			"handle":        db.Handle,
+			"database_type": db.GetType(),
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%d/%s/%s", envID, databaseType, handleRegex))
+	_ = d.Set("databases", results)
+	return nil
+}
+
+// listDatabases returns every database the user can access, following
+// pagination
+func listDatabases(ctx context.Context, meta interface{}) ([]aptibleapi.Database, error) {
+	client := meta.(*providerMetadata).Client
+	return listAllPages(func(page int32) ([]aptibleapi.Database, int32, int32, error) {
+		resp, _, err := client.DatabasesAPI.ListDatabases(ctx).Page(page).Execute()
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		return resp.Embedded.Databases, resp.PerPage, resp.TotalCount, nil
+	})
+}
@@ -0,0 +1,80 @@
+package aptible
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aptible/go-deploy/aptible"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDatabases_validation(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PlanOnly: true,
+				Config: `
+	data "aptible_databases" "test" {
+		handle_regex = "["
+	}
+`,
+				ExpectError: regexp.MustCompile(`"handle_regex": error parsing regexp`),
+			},
+			{
+				PlanOnly: true,
+				Config: `
+	data "aptible_databases" "test" {
+		database_type = "notadb"
+	}
+`,
+				ExpectError: regexp.MustCompile(`expected database_type to be one of`),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceDatabases_basic(t *testing.T) {
+	dbHandle := acctest.RandString(10)
+
+	WithTestAccEnvironment(t, func(env aptible.Environment) {
+		resource.ParallelTest(t, resource.TestCase{
+			PreCheck:          func() { testAccPreCheck(t) },
+			ProviderFactories: testAccProviderFactories,
+			CheckDestroy:      testAccCheckDatabaseDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccAptibleDatabasesDataSource(env.ID, dbHandle),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("data.aptible_databases.postgresql", "databases.#", "1"),
+						resource.TestCheckResourceAttrPair("aptible_database.test", "database_id", "data.aptible_databases.postgresql", "databases.0.database_id"),
+						resource.TestCheckResourceAttrPair("aptible_database.test", "env_id", "data.aptible_databases.postgresql", "databases.0.env_id"),
+						resource.TestCheckResourceAttr("data.aptible_databases.postgresql", "databases.0.handle", dbHandle),
+						resource.TestCheckResourceAttr("data.aptible_databases.postgresql", "databases.0.database_type", "postgresql"),
+						resource.TestCheckResourceAttr("data.aptible_databases.redis", "databases.#", "0"),
+					),
+				},
+			},
+		})
+	})
+}
+
+func testAccAptibleDatabasesDataSource(envId int64, dbHandle string) string {
+	return fmt.Sprintf(`
+%s
+
+	data "aptible_databases" "postgresql" {
+		env_id = aptible_database.test.env_id
+		handle_regex = "^${aptible_database.test.handle}$"
+		database_type = "postgresql"
+	}
+
+	data "aptible_databases" "redis" {
+		env_id = aptible_database.test.env_id
+		database_type = "redis"
+	}
+`, testAccAptibleDatabaseBasic(envId, dbHandle))
+}
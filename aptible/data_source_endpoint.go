@@ -0,0 +1,299 @@
+package aptible
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceEndpoint() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceEndpointRead,
+		Schema: map[string]*schema.Schema{
+			"endpoint_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"endpoint_id", "resource_id"},
+			},
+			"resource_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"resource_type"},
+			},
+			"resource_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(validResourceTypes, false),
+				RequiredWith: []string{"resource_id"},
+			},
+			"process_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"env_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"endpoint_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"default_domain": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"managed": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"domain": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"certificate_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"internal": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"container_ports": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"container_port": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"ip_filtering": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cidr": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"platform": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"virtual_domain": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"external_hostname": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"dns_validation_record": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"dns_validation_value": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"acme_challenges": acmeChallengesSchema(),
+			"shared": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"load_balancing_algorithm_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"force_ssl": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"maintenance_page_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"idle_timeout": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"release_healthcheck_timeout": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"strict_health_checks": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"show_elb_healthchecks": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"ssl_protocols_override": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ssl_ciphers_override": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"disable_weak_cipher_suites": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceEndpointRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	endpointID := int32(d.Get("endpoint_id").(int))
+
+	if endpointID == 0 {
+		var diags diag.Diagnostics
+		endpointID, diags = findEndpointForResource(ctx, d, meta)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	// The resource's read sets the same attributes this data source exposes,
+	// including the ACME challenge and the endpoint's settings
+	d.SetId(strconv.Itoa(int(endpointID)))
+	_ = d.Set("endpoint_id", int(endpointID))
+	diags := resourceEndpointRead(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	if d.Id() == "" {
+		return diag.Errorf("Endpoint with ID: %d not found", endpointID)
+	}
+
+	return diags
+}
+
+// findEndpointForResource returns the ID of the only endpoint on the service
+// identified by resource_id, resource_type and process_type
+func findEndpointForResource(ctx context.Context, d *schema.ResourceData, meta interface{}) (int32, diag.Diagnostics) {
+	m := meta.(*providerMetadata)
+	client := m.Client
+	ctx = m.APIContext(ctx)
+	resourceID := int32(d.Get("resource_id").(int))
+	resourceType := d.Get("resource_type").(string)
+	processType := d.Get("process_type").(string)
+
+	var serviceID int32
+	if resourceType == "app" {
+		if processType == "" {
+			return 0, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Validation Error",
+				Detail:   "process_type is required when looking up an app's endpoint",
+			}}
+		}
+
+		log.Printf("Getting Endpoint for process type: %s of App with ID: %d\n", processType, resourceID)
+
+		app, _, err := client.AppsAPI.GetApp(ctx, resourceID).Execute()
+		if err != nil {
+			return 0, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error fetching app with ID: %d", resourceID),
+				Detail:   err.Error(),
+			}}
+		}
+		for _, s := range app.Embedded.Services {
+			if s.GetProcessType() == processType {
+				serviceID = s.Id
+				break
+			}
+		}
+		if serviceID == 0 {
+			return 0, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Service %q not found", processType),
+				Detail:   fmt.Sprintf("The app with ID: %d has no service with process type %q", resourceID, processType),
+			}}
+		}
+	} else {
+		log.Printf("Getting Endpoint for Database with ID: %d\n", resourceID)
+
+		database, _, err := client.DatabasesAPI.GetDatabase(ctx, resourceID).Execute()
+		if err != nil {
+			return 0, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error fetching database with ID: %d", resourceID),
+				Detail:   err.Error(),
+			}}
+		}
+		serviceID = ExtractIdFromLink(database.Links.Service.GetHref())
+		if serviceID == 0 {
+			return 0, diag.Errorf("Could not find the service for database with ID: %d", resourceID)
+		}
+	}
+
+	endpoints, err := listEndpointsForService(ctx, meta, serviceID)
+	if err != nil {
+		return 0, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error fetching endpoints for service with ID: %d", serviceID),
+			Detail:   err.Error(),
+		}}
+	}
+
+	switch len(endpoints) {
+	case 0:
+		return 0, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Endpoint not found",
+			Detail:   fmt.Sprintf("There is no endpoint for the %s with ID: %d", resourceType, resourceID),
+		}}
+	case 1:
+		return endpoints[0].Id, nil
+	}
+
+	ids := make([]string, len(endpoints))
+	for i, e := range endpoints {
+		ids[i] = strconv.Itoa(int(e.Id))
+	}
+	return 0, diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  "Multiple endpoints found",
+		Detail: fmt.Sprintf(
+			"The %s with ID: %d has %d endpoints (%s), set endpoint_id to choose one",
+			resourceType, resourceID, len(endpoints), strings.Join(ids, ", "),
+		),
+	}}
+}
+
+// listEndpointsForService returns every endpoint on the service, following
+// pagination
+func listEndpointsForService(ctx context.Context, meta interface{}, serviceID int32) ([]aptibleapi.Vhost, error) {
+	client := meta.(*providerMetadata).Client
+	return listAllPages(func(page int32) ([]aptibleapi.Vhost, int32, int32, error) {
+		resp, _, err := client.VhostsAPI.ListVhostsForService(ctx, serviceID).Page(page).Execute()
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		return resp.Embedded.Vhosts, resp.PerPage, resp.TotalCount, nil
+	})
+}
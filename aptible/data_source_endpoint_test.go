@@ -0,0 +1,85 @@
+package aptible
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceEndpoint_validation(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PlanOnly:    true,
+				Config:      `data "aptible_endpoint" "test" {}`,
+				ExpectError: regexp.MustCompile("one of `endpoint_id,resource_id` must be specified"),
+			},
+			{
+				PlanOnly: true,
+				Config: `
+	data "aptible_endpoint" "test" {
+		endpoint_id = 1
+		resource_id = 1
+		resource_type = "app"
+	}
+`,
+				ExpectError: regexp.MustCompile("only one of `endpoint_id,resource_id` can be specified"),
+			},
+			{
+				PlanOnly: true,
+				Config: `
+	data "aptible_endpoint" "test" {
+		resource_id = 1
+	}
+`,
+				ExpectError: regexp.MustCompile("all of `resource_id,resource_type` must be specified"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceEndpoint_basic(t *testing.T) {
+	appHandle := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckEndpointDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAptibleEndpointDataSource(appHandle),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("aptible_endpoint.test", "endpoint_id", "data.aptible_endpoint.by_id", "endpoint_id"),
+					resource.TestCheckResourceAttrPair("aptible_endpoint.test", "external_hostname", "data.aptible_endpoint.by_id", "external_hostname"),
+					resource.TestCheckResourceAttrPair("aptible_endpoint.test", "virtual_domain", "data.aptible_endpoint.by_id", "virtual_domain"),
+					resource.TestCheckResourceAttr("data.aptible_endpoint.by_id", "container_port", "80"),
+					resource.TestCheckResourceAttr("data.aptible_endpoint.by_id", "process_type", "cmd"),
+					resource.TestCheckResourceAttrPair("aptible_endpoint.test", "endpoint_id", "data.aptible_endpoint.by_resource", "endpoint_id"),
+					resource.TestCheckResourceAttrPair("aptible_endpoint.test", "external_hostname", "data.aptible_endpoint.by_resource", "external_hostname"),
+					resource.TestCheckResourceAttr("data.aptible_endpoint.by_resource", "endpoint_type", "https"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAptibleEndpointDataSource(appHandle string) string {
+	return fmt.Sprintf(`
+%s
+
+	data "aptible_endpoint" "by_id" {
+		endpoint_id = aptible_endpoint.test.endpoint_id
+	}
+
+	data "aptible_endpoint" "by_resource" {
+		resource_id = aptible_endpoint.test.resource_id
+		resource_type = "app"
+		process_type = "cmd"
+	}
+`, testAccAptibleEndpointAppContainerPort(appHandle))
+}
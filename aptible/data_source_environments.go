@@ -0,0 +1,118 @@
+package aptible
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceEnvironments() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceEnvironmentsRead,
+		Schema: map[string]*schema.Schema{
+			"stack_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"org_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"handle_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"environments": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"env_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"handle": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"stack_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"org_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceEnvironmentsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	ctx = m.APIContext(ctx)
+	stackID := int32(d.Get("stack_id").(int))
+	orgID := d.Get("org_id").(string)
+	handleRegex := regexp.MustCompile(d.Get("handle_regex").(string))
+
+	log.Printf("Listing Environments with stack ID: %d, org ID: %q\n", stackID, orgID)
+
+	accounts, err := listAccounts(ctx, meta, stackID)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Error listing environments",
+			Detail:   err.Error(),
+		}}
+	}
+
+	environments := []map[string]interface{}{}
+	for _, a := range accounts {
+		links := a.GetLinks()
+		accountOrgID := ExtractUuidFromLink(links.Organization.GetHref())
+		if orgID != "" && accountOrgID != orgID {
+			continue
+		}
+		if !handleRegex.MatchString(a.Handle) {
+			continue
+		}
+		environments = append(environments, map[string]interface{}{
+			"env_id":   int(a.Id),
+			"handle":   a.Handle,
+			"stack_id": int(ExtractIdFromLink(links.Stack.GetHref())),
+			"org_id":   accountOrgID,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%d/%s/%s", stackID, orgID, handleRegex))
+	_ = d.Set("environments", environments)
+	return nil
+}
+
+// listAccounts returns every environment the user can access, or only those on
+// the stack when stackID isn't 0, following pagination
+func listAccounts(ctx context.Context, meta interface{}, stackID int32) ([]aptibleapi.Account, error) {
+	client := meta.(*providerMetadata).Client
+	return listAllPages(func(page int32) ([]aptibleapi.Account, int32, int32, error) {
+		var resp *aptibleapi.ListAccountsForStack200Response
+		var err error
+		if stackID != 0 {
+			resp, _, err = client.AccountsAPI.ListAccountsForStack(ctx, stackID).Page(page).Execute()
+		} else {
+			resp, _, err = client.AccountsAPI.ListAccounts(ctx).Page(page).Execute()
+		}
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		return resp.Embedded.Accounts, resp.PerPage, resp.TotalCount, nil
+	})
+}
@@ -0,0 +1,63 @@
+package aptible
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceEnvironments_validation(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PlanOnly: true,
+				Config: `
+	data "aptible_environments" "test" {
+		handle_regex = "["
+	}
+`,
+				ExpectError: regexp.MustCompile(`"handle_regex": error parsing regexp`),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceEnvironments_basic(t *testing.T) {
+	rHandle := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckEnvironmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAptibleEnvironmentsDataSource(rHandle),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aptible_environments.test", "environments.#", "1"),
+					resource.TestCheckResourceAttrPair("aptible_environment.test", "env_id", "data.aptible_environments.test", "environments.0.env_id"),
+					resource.TestCheckResourceAttr("data.aptible_environments.test", "environments.0.handle", rHandle),
+					resource.TestCheckResourceAttr("data.aptible_environments.test", "environments.0.stack_id", strconv.Itoa(testStackId)),
+					resource.TestCheckResourceAttr("data.aptible_environments.test", "environments.0.org_id", testOrganizationId),
+				),
+			},
+		},
+	})
+}
+
+func testAccAptibleEnvironmentsDataSource(handle string) string {
+	return fmt.Sprintf(`
+%s
+
+	data "aptible_environments" "test" {
+		stack_id = aptible_environment.test.stack_id
+		org_id = aptible_environment.test.org_id
+		handle_regex = "^${aptible_environment.test.handle}$"
+	}
+`, testAccAptibleEnvironment(handle))
+}
@@ -0,0 +1,132 @@
+package aptible
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceStacks() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStacksRead,
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"dedicated": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"stacks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"stack_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"dedicated": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"org_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"outbound_ip_addresses": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"memory_limits": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"cpu_limits": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"intrusion_detection": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStacksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	ctx = m.APIContext(ctx)
+	region := d.Get("region").(string)
+	dedicated, filterDedicated := d.GetOkExists("dedicated") //nolint:staticcheck
+
+	log.Println("Listing Stacks")
+
+	stacks, err := listStacks(ctx, meta)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Error listing stacks",
+			Detail:   err.Error(),
+		}}
+	}
+
+	results := []map[string]interface{}{}
+	for _, s := range stacks {
+		// Only dedicated stacks belong to an organization
+		orgID := ExtractUuidFromLink(s.GetLinks().Organization.GetHref())
+		isDedicated := orgID != ""
+		if region != "" && s.Region != region {
+			continue
+		}
+		if filterDedicated && isDedicated != dedicated.(bool) {
+			continue
+		}
+		results = append(results, map[string]interface{}{
+			"stack_id":              int(s.Id),
+			"name":                  s.Name,
+			"region":                s.Region,
+			"dedicated":             isDedicated,
+			"org_id":                orgID,
+			"outbound_ip_addresses": s.OutboundIpAddresses,
+			"memory_limits":         s.MemoryLimits,
+			"cpu_limits":            s.CpuLimits,
+			"intrusion_detection":   s.IntrusionDetection,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s/%v", region, dedicated))
+	_ = d.Set("stacks", results)
+	return nil
+}
+
+// listStacks returns every stack the user can access, following pagination
+func listStacks(ctx context.Context, meta interface{}) ([]aptibleapi.Stack, error) {
+	client := meta.(*providerMetadata).Client
+	return listAllPages(func(page int32) ([]aptibleapi.Stack, int32, int32, error) {
+		resp, _, err := client.StacksAPI.ListStacks(ctx).Page(page).Execute()
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		return resp.Embedded.Stacks, resp.PerPage, resp.TotalCount, nil
+	})
+}
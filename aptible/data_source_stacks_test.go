@@ -0,0 +1,35 @@
+package aptible
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceStacks_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "aptible_stacks" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.aptible_stacks.test", "stacks.0.stack_id"),
+					resource.TestCheckResourceAttrSet("data.aptible_stacks.test", "stacks.0.name"),
+					resource.TestCheckResourceAttrSet("data.aptible_stacks.test", "stacks.0.region"),
+				),
+			},
+			{
+				Config: `
+	data "aptible_stacks" "test" {
+		dedicated = false
+	}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aptible_stacks.test", "stacks.0.dedicated", "false"),
+					resource.TestCheckResourceAttr("data.aptible_stacks.test", "stacks.0.org_id", ""),
+				),
+			},
+		},
+	})
+}
@@ -1,6 +1,10 @@
 package aptible
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
 
 func suppressDefaultDatabaseVersion(_, old, new string, _ *schema.ResourceData) bool {
 	// If the new value is empty, ignore the diff because our API will handle setting a default.
@@ -11,3 +15,19 @@ func suppressDefaultDatabaseVersion(_, old, new string, _ *schema.ResourceData)
 	}
 	return old == new
 }
+
+func suppressEquivalentPEM(_, old, new string, _ *schema.ResourceData) bool {
+	// Aptible may return PEM data with different line endings or surrounding
+	// whitespace than what was uploaded, which isn't an actual change.
+	return normalizePEM(old) == normalizePEM(new)
+}
+
+func normalizePEM(pem string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(pem, "\r\n", "\n"), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
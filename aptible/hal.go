@@ -16,3 +16,13 @@ func ExtractIdFromLink(relation string) int32 {
 	val, _ := strconv.ParseInt(segments[len(segments)-1], 10, 32)
 	return int32(val)
 }
+
+// ExtractUuidFromLink returns the last segment of the link, for resources such
+// as organizations that are identified by a UUID rather than an integer
+func ExtractUuidFromLink(relation string) string {
+	if relation == "" {
+		return ""
+	}
+	segments := strings.Split(relation, "/")
+	return segments[len(segments)-1]
+}
@@ -0,0 +1,269 @@
+package aptible
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+)
+
+// operationStatus is the terminal state of an operation that was waited on
+type operationStatus string
+
+const (
+	operationSucceeded operationStatus = "succeeded"
+	operationFailed    operationStatus = "failed"
+	// The operation (and so the resource it belongs to) no longer exists
+	operationDeleted operationStatus = "deleted"
+	// The operation was cancelled or aborted before it completed
+	operationCancelled operationStatus = "cancelled"
+)
+
+// operationBackoff controls how often operations are polled. The delay starts
+// at Initial and grows by Multiplier up to Max, and each delay is randomized by
+// up to +/- Jitter (a fraction of the delay) so that resources created in
+// parallel don't poll the API in lockstep.
+type operationBackoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+	Jitter     float64
+}
+
+// How operations are polled by default, overridden in tests
+var defaultOperationBackoff = operationBackoff{
+	Initial:    2 * time.Second,
+	Max:        30 * time.Second,
+	Multiplier: 1.5,
+	Jitter:     0.2,
+}
+
+// delay returns the randomized delay before the given (zero-indexed) attempt
+func (b operationBackoff) delay(attempt int) time.Duration {
+	d := float64(b.Initial)
+	for i := 0; i < attempt && d < float64(b.Max); i++ {
+		d *= b.Multiplier
+	}
+	if d > float64(b.Max) {
+		d = float64(b.Max)
+	}
+	if b.Jitter > 0 {
+		d += d * b.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(d)
+}
+
+// operationResult is the outcome of waiting on an operation
+type operationResult struct {
+	ID     int32
+	Status operationStatus
+	// The last version of the operation that was fetched, nil if the operation
+	// was deleted before it could be fetched
+	Operation *aptibleapi.Operation
+}
+
+// operationPoller waits for operations using OperationsAPI.GetOperation
+type operationPoller struct {
+	meta    *providerMetadata
+	backoff operationBackoff
+}
+
+func newOperationPoller(meta interface{}) *operationPoller {
+	return &operationPoller{
+		meta:    meta.(*providerMetadata),
+		backoff: defaultOperationBackoff,
+	}
+}
+
+// Wait polls the operation until it reaches a terminal state or ctx is done.
+// The SDK sets the deadline on ctx from the resource's Timeouts, so operations
+// that outlive the configured timeout return an error naming the operation and
+// the last status we saw instead of blocking forever. An error is only returned
+// when the operation's status could not be determined; failed and cancelled
+// operations are reported through the result's Status.
+func (p *operationPoller) Wait(ctx context.Context, operationID int32) (*operationResult, error) {
+	ctx = p.meta.APIContext(ctx)
+	result := &operationResult{ID: operationID}
+	status := "unknown"
+
+	for attempt := 0; ; attempt++ {
+		op, resp, err := p.meta.Client.OperationsAPI.GetOperation(ctx, operationID).Execute()
+		switch {
+		case resp != nil && resp.StatusCode == http.StatusNotFound:
+			result.Status = operationDeleted
+			return result, nil
+		case ctx.Err() != nil:
+			return result, operationTimeoutError(ctx, operationID, status)
+		case err != nil:
+			return result, fmt.Errorf("there was an error when getting the operation for op id: %d: %w", operationID, err)
+		}
+
+		result.Operation = op
+		status = op.GetStatus()
+		switch {
+		case status == string(operationSucceeded):
+			result.Status = operationSucceeded
+			return result, nil
+		case status == string(operationFailed) && (op.GetCancelled() || op.GetAborted()):
+			result.Status = operationCancelled
+			return result, nil
+		case status == string(operationFailed):
+			result.Status = operationFailed
+			return result, nil
+		}
+
+		delay := p.backoff.delay(attempt)
+		log.Printf("[DEBUG] Operation %d is %s, checking again in %s\n", operationID, status, delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, operationTimeoutError(ctx, operationID, status)
+		case <-timer.C:
+		}
+	}
+}
+
+func operationTimeoutError(ctx context.Context, operationID int32, status string) error {
+	return fmt.Errorf(
+		"stopped waiting for operation %d, last known status: %s. The operation may still complete on Aptible, increase the resource's timeouts if it needs more time: %w",
+		operationID, status, ctx.Err(),
+	)
+}
+
+// waitForOperation blocks until the operation completes and returns an error
+// unless it succeeded. The returned bool is true when the operation (and so
+// the resource it belongs to) no longer exists.
+func waitForOperation(ctx context.Context, meta interface{}, operationID int32) (bool, error) {
+	result, err := newOperationPoller(meta).Wait(ctx, operationID)
+	if err != nil {
+		return false, err
+	}
+
+	switch result.Status {
+	case operationDeleted:
+		return true, nil
+	case operationFailed, operationCancelled:
+		return false, newOperationError(ctx, meta, result)
+	}
+	return false, nil
+}
+
+// How many lines of a failed operation's log are included in its error
+const operationLogTailLines = 50
+
+// operationError describes an operation that failed or was cancelled. It
+// includes the end of the operation's log so that failures (e.g. a Docker
+// image that doesn't build) can be diagnosed from the Terraform output.
+type operationError struct {
+	Status    operationStatus
+	Operation *aptibleapi.Operation
+	Logs      string
+	LogsErr   error
+}
+
+func newOperationError(ctx context.Context, meta interface{}, result *operationResult) *operationError {
+	logs, err := operationLogs(ctx, meta, result.ID, operationLogTailLines)
+	return &operationError{
+		Status:    result.Status,
+		Operation: result.Operation,
+		Logs:      logs,
+		LogsErr:   err,
+	}
+}
+
+func (e *operationError) Error() string {
+	op := e.Operation
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s operation %d %s", op.GetType(), op.GetId(), e.Status)
+	if op.GetUserName() != "" || op.GetUserEmail() != "" {
+		fmt.Fprintf(&b, "\nStarted by: %s <%s>", op.GetUserName(), op.GetUserEmail())
+	}
+
+	switch {
+	case e.LogsErr != nil:
+		fmt.Fprintf(&b, "\nUnable to retrieve the operation logs: %s", e.LogsErr)
+	case e.Logs != "":
+		lines := strings.Count(e.Logs, "\n") + 1
+		if lines == 1 {
+			fmt.Fprintf(&b, "\nOperation logs (last line):\n%s", e.Logs)
+		} else {
+			fmt.Fprintf(&b, "\nOperation logs (last %d lines):\n%s", lines, e.Logs)
+		}
+	}
+
+	return b.String()
+}
+
+// operationLogs returns the last n lines of an operation's log. The API
+// redirects to the log's storage location, so this isn't part of the generated
+// client. Logs can be large, so only the last n lines are kept in memory.
+func operationLogs(ctx context.Context, meta interface{}, operationID int32, n int) (string, error) {
+	m := meta.(*providerMetadata)
+	cfg := m.Client.GetConfig()
+
+	baseURL, err := cfg.Servers.URL(0, nil)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/operations/%d/logs", baseURL, operationID), nil)
+	if err != nil {
+		return "", err
+	}
+	// Go doesn't forward the Authorization header when redirected to another
+	// host, so the token isn't sent to the log's storage location.
+	req.Header.Set("Authorization", "Bearer "+m.tokens.Token())
+
+	resp, err := cfg.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("GET %s: %s - %s", req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return tailLines(resp.Body, n)
+}
+
+// tailLines returns the last n lines read from r, ignoring any trailing
+// newlines. Lines are kept in a ring buffer as they're read.
+func tailLines(r io.Reader, n int) (string, error) {
+	if n <= 0 {
+		return "", nil
+	}
+
+	ring := make([]string, n)
+	count := 0
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if line != "" {
+			ring[count%n] = strings.TrimSuffix(line, "\n")
+			count++
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	lines := make([]string, 0, n)
+	for i := max(0, count-n); i < count; i++ {
+		lines = append(lines, ring[i%n])
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n"), nil
+}
@@ -0,0 +1,340 @@
+package aptible
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+)
+
+// writeTestOperation responds with op, filling in the properties the client
+// requires to be present
+func writeTestOperation(w http.ResponseWriter, op aptibleapi.Operation) {
+	if op.Env == nil {
+		op.Env = map[string]interface{}{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(op)
+}
+
+// testProviderMetadata returns metadata whose API client and auth requests
+// talk to server
+func testProviderMetadata(server *httptest.Server) *providerMetadata {
+	return &providerMetadata{
+		Client:      newAPIClient(server.URL, server.Client()),
+		tokens:      newTokenSource(&providerConfig{}, "token"),
+		authRootURL: server.URL,
+		httpClient:  server.Client(),
+	}
+}
+
+// testOperationServer serves operation 42, moving through ops on each request
+// and repeating the last one. A nil entry responds with a 404.
+func testOperationServer(t *testing.T, ops []*aptibleapi.Operation) *httptest.Server {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/operations/42" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		op := ops[len(ops)-1]
+		if calls < len(ops) {
+			op = ops[calls]
+		}
+		calls++
+
+		if op == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeTestOperation(w, *op)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testOperation(status string) *aptibleapi.Operation {
+	return &aptibleapi.Operation{Id: 42, Type: "provision", Status: status}
+}
+
+func useFastOperationBackoff(t *testing.T) {
+	backoff := defaultOperationBackoff
+	defaultOperationBackoff = operationBackoff{Initial: time.Millisecond, Max: 5 * time.Millisecond, Multiplier: 2}
+	t.Cleanup(func() { defaultOperationBackoff = backoff })
+}
+
+func TestOperationBackoffDelay(t *testing.T) {
+	b := operationBackoff{Initial: time.Second, Max: 10 * time.Second, Multiplier: 2}
+
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		if got := b.delay(attempt); got != want {
+			t.Errorf("delay(%d) = %s, want %s", attempt, got, want)
+		}
+	}
+
+	b.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := b.delay(1); got < time.Second || got > 3*time.Second {
+			t.Fatalf("delay(1) = %s, want it within 50%% of 2s", got)
+		}
+	}
+}
+
+func TestOperationPollerWait(t *testing.T) {
+	useFastOperationBackoff(t)
+
+	cancelled := testOperation("failed")
+	cancelled.Cancelled = true
+	aborted := testOperation("failed")
+	aborted.Aborted = true
+
+	tests := []struct {
+		name       string
+		ops        []*aptibleapi.Operation
+		timeout    time.Duration
+		wantStatus operationStatus
+		wantErr    string
+	}{
+		{
+			name:       "succeeded",
+			ops:        []*aptibleapi.Operation{testOperation("queued"), testOperation("running"), testOperation("succeeded")},
+			wantStatus: operationSucceeded,
+		},
+		{
+			name:       "failed",
+			ops:        []*aptibleapi.Operation{testOperation("running"), testOperation("failed")},
+			wantStatus: operationFailed,
+		},
+		{
+			name:       "deleted",
+			ops:        []*aptibleapi.Operation{testOperation("running"), nil},
+			wantStatus: operationDeleted,
+		},
+		{
+			name:       "cancelled",
+			ops:        []*aptibleapi.Operation{testOperation("running"), cancelled},
+			wantStatus: operationCancelled,
+		},
+		{
+			name:       "aborted",
+			ops:        []*aptibleapi.Operation{aborted},
+			wantStatus: operationCancelled,
+		},
+		{
+			name:    "deadline exceeded",
+			ops:     []*aptibleapi.Operation{testOperation("running")},
+			timeout: 20 * time.Millisecond,
+			wantErr: "stopped waiting for operation 42, last known status: running",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testOperationServer(t, tt.ops)
+
+			ctx := context.Background()
+			if tt.timeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			result, err := newOperationPoller(testProviderMetadata(server)).Wait(ctx, 42)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Wait() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Wait() unexpected error: %v", err)
+			}
+			if result.ID != 42 || result.Status != tt.wantStatus {
+				t.Errorf("Wait() = %d %s, want 42 %s", result.ID, result.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestOperationPollerWaitCancelledContext(t *testing.T) {
+	server := testOperationServer(t, []*aptibleapi.Operation{testOperation("running")})
+	poller := newOperationPoller(testProviderMetadata(server))
+	poller.backoff = operationBackoff{Initial: time.Hour, Max: time.Hour, Multiplier: 1}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := poller.Wait(ctx, 42)
+	if err == nil || !strings.Contains(err.Error(), "context canceled") {
+		t.Fatalf("Wait() error = %v, want a context cancellation error", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Wait() took %s to notice the cancelled context", elapsed)
+	}
+}
+
+func TestOperationPollerWaitAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, err := newOperationPoller(testProviderMetadata(server)).Wait(context.Background(), 42)
+	if err == nil || !strings.Contains(err.Error(), "error when getting the operation for op id: 42") {
+		t.Fatalf("Wait() error = %v, want an error naming the operation", err)
+	}
+}
+
+func TestWaitForOperation(t *testing.T) {
+	useFastOperationBackoff(t)
+
+	cancelled := testOperation("failed")
+	cancelled.Cancelled = true
+
+	tests := []struct {
+		name        string
+		ops         []*aptibleapi.Operation
+		wantDeleted bool
+		wantErr     string
+	}{
+		{
+			name: "returns once the operation succeeds",
+			ops:  []*aptibleapi.Operation{testOperation("running"), testOperation("succeeded")},
+		},
+		{
+			name:    "returns an error when the operation fails",
+			ops:     []*aptibleapi.Operation{testOperation("failed")},
+			wantErr: "provision operation 42 failed",
+		},
+		{
+			name:    "returns an error when the operation is cancelled",
+			ops:     []*aptibleapi.Operation{cancelled},
+			wantErr: "provision operation 42 cancelled",
+		},
+		{
+			name:        "reports deleted operations",
+			ops:         []*aptibleapi.Operation{nil},
+			wantDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testOperationServer(t, tt.ops)
+
+			deleted, err := waitForOperation(context.Background(), testProviderMetadata(server), 42)
+			if deleted != tt.wantDeleted {
+				t.Errorf("waitForOperation() deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("waitForOperation() unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("waitForOperation() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWaitForOperationIncludesLogs(t *testing.T) {
+	useFastOperationBackoff(t)
+
+	var logs strings.Builder
+	for i := 1; i <= operationLogTailLines+10; i++ {
+		fmt.Fprintf(&logs, "line %d\n", i)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/operations/42", func(w http.ResponseWriter, _ *http.Request) {
+		op := testOperation("failed")
+		op.Type = "deploy"
+		op.UserName = "Jane Doe"
+		op.UserEmail = "jane@example.com"
+		writeTestOperation(w, *op)
+	})
+	mux.HandleFunc("/operations/42/logs", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		http.Redirect(w, r, "/storage/42.log", http.StatusFound)
+	})
+	mux.HandleFunc("/storage/42.log", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(logs.String()))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	_, err := waitForOperation(context.Background(), testProviderMetadata(server), 42)
+	if err == nil {
+		t.Fatal("waitForOperation() expected an error")
+	}
+
+	got := err.Error()
+	for _, want := range []string{
+		"deploy operation 42 failed",
+		"Started by: Jane Doe <jane@example.com>",
+		fmt.Sprintf("Operation logs (last %d lines):\nline 11\n", operationLogTailLines),
+		"line 60",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("waitForOperation() error = %q, want it to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "line 10\n") {
+		t.Errorf("waitForOperation() error = %q, want only the last %d lines", got, operationLogTailLines)
+	}
+}
+
+func TestOperationErrorLogLines(t *testing.T) {
+	op := testOperation("failed")
+	op.Type = "deploy"
+
+	tests := []struct {
+		logs string
+		want string
+	}{
+		{logs: "only line", want: "Operation logs (last line):\nonly line"},
+		{logs: "one\ntwo\nthree", want: "Operation logs (last 3 lines):\none\ntwo\nthree"},
+	}
+
+	for _, tt := range tests {
+		err := &operationError{Status: operationFailed, Operation: op, Logs: tt.logs}
+		if got := err.Error(); !strings.HasSuffix(got, tt.want) {
+			t.Errorf("Error() = %q, want it to end with %q", got, tt.want)
+		}
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{in: "a\nb\nc\n", n: 2, want: "b\nc"},
+		{in: "a\nb", n: 5, want: "a\nb"},
+		{in: "a\n\nb\nc\nd\ne\n", n: 4, want: "b\nc\nd\ne"},
+		{in: "a\nb\n\n", n: 5, want: "a\nb"},
+		{in: "", n: 5, want: ""},
+		{in: "a\nb", n: 0, want: ""},
+	}
+
+	for _, tt := range tests {
+		got, err := tailLines(strings.NewReader(tt.in), tt.n)
+		if err != nil {
+			t.Fatalf("tailLines(%q, %d) unexpected error: %v", tt.in, tt.n, err)
+		}
+		if got != tt.want {
+			t.Errorf("tailLines(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+		}
+	}
+}
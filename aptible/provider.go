@@ -3,7 +3,7 @@ package aptible
 import (
 	"context"
 	"log"
-	"os"
+	"net/http"
 
 	"github.com/aptible/aptible-api-go/aptibleapi"
 	"github.com/aptible/go-deploy/aptible"
@@ -14,77 +14,116 @@ import (
 func Provider() *schema.Provider {
 	return &schema.Provider{
 		ResourcesMap: map[string]*schema.Resource{
-			"aptible_app":          resourceApp(),
-			"aptible_database":     resourceDatabase(),
-			"aptible_environment":  resourceEnvironment(),
-			"aptible_endpoint":     resourceEndpoint(),
-			"aptible_replica":      resourceReplica(),
-			"aptible_log_drain":    resourceLogDrain(),
-			"aptible_metric_drain": resourceMetricDrain(),
+			"aptible_app":                      resourceApp(),
+			"aptible_certificate":              resourceCertificate(),
+			"aptible_database":                 resourceDatabase(),
+			"aptible_database_backup":          resourceDatabaseBackup(),
+			"aptible_environment":              resourceEnvironment(),
+			"aptible_endpoint":                 resourceEndpoint(),
+			"aptible_endpoint_acme_validation": resourceEndpointAcmeValidation(),
+			"aptible_replica":                  resourceReplica(),
+			"aptible_log_drain":                resourceLogDrain(),
+			"aptible_metric_drain":             resourceMetricDrain(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
+			"aptible_app":                     dataSourceApp(),
+			"aptible_apps":                    dataSourceApps(),
+			"aptible_database":                dataSourceDatabase(),
+			"aptible_databases":               dataSourceDatabases(),
+			"aptible_database_backups":        dataSourceDatabaseBackups(),
+			"aptible_database_images":         dataSourceDatabaseImages(),
+			"aptible_endpoint":                dataSourceEndpoint(),
 			"aptible_environment":             dataSourceEnvironment(),
+			"aptible_environments":            dataSourceEnvironments(),
 			"aptible_backup_retention_policy": dataSourceBackupRetentionPolicy(),
 			"aptible_stack":                   dataSourceStack(),
+			"aptible_stacks":                  dataSourceStacks(),
+		},
+		Schema: map[string]*schema.Schema{
+			"access_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("APTIBLE_ACCESS_TOKEN", nil),
+				Description: "The access token used to authenticate with Aptible. Defaults to APTIBLE_ACCESS_TOKEN.",
+			},
+			"api_root_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("APTIBLE_API_ROOT_URL", defaultAPIRootURL),
+				ValidateFunc: validateURL,
+				Description:  "The root URL of the Aptible API. Defaults to APTIBLE_API_ROOT_URL.",
+			},
+			"auth_root_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("APTIBLE_AUTH_ROOT_URL", defaultAuthRootURL),
+				ValidateFunc: validateURL,
+				Description:  "The root URL of the Aptible auth server. Defaults to APTIBLE_AUTH_ROOT_URL.",
+			},
+			"username": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("APTIBLE_USERNAME", nil),
+				RequiredWith: []string{"password"},
+				Description:  "The email used to log in to Aptible. Defaults to APTIBLE_USERNAME.",
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.EnvDefaultFunc("APTIBLE_PASSWORD", nil),
+				RequiredWith: []string{"username"},
+				Description:  "The password used to log in to Aptible. Defaults to APTIBLE_PASSWORD.",
+			},
 		},
 		ConfigureContextFunc: providerConfigureWithContext,
 	}
 }
 
-func providerConfigureWithContext(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	var diags diag.Diagnostics
-
-	client, err := aptible.SetUpClient()
-	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "There was an error when initializing the provider.",
-			Detail:   "There was an error when initializing the provider.",
-		})
-		log.Println("[ERR] Error in attempting to start the provider", err)
-		return nil, diags
+func providerConfigureWithContext(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	config := &providerConfig{
+		AccessToken: d.Get("access_token").(string),
+		APIRootURL:  d.Get("api_root_url").(string),
+		AuthRootURL: d.Get("auth_root_url").(string),
+		Username:    d.Get("username").(string),
+		Password:    d.Get("password").(string),
 	}
 
-	token, err := aptible.GetToken()
+	meta, err := config.metadata()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "There was an error when initializing the provider.",
-			Detail:   "There was an error when initializing the provider.",
-		})
 		log.Println("[ERR] Error in attempting to start the provider", err)
-		return nil, diags
+		return nil, diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "There was an error when initializing the provider.",
+				Detail:   err.Error(),
+			},
+		}
 	}
 
-	return &providerMetadata{
-		LegacyClient: client,
-		Client:       aptibleapi.NewAPIClient(aptibleapi.NewAPIConfiguration()),
-		Token:        token,
-	}, nil
+	return meta, nil
 }
 
 type providerMetadata struct {
 	LegacyClient *aptible.Client
 	Client       *aptibleapi.APIClient
-	Token        string
+	tokens       *tokenSource
+
+	// authRootURL and httpClient are used for requests to this instance's
+	// auth server
+	authRootURL string
+	httpClient  *http.Client
 }
 
 // Configures the provided context to work with aptibleapi.APIClient requests
 func (m *providerMetadata) APIContext(ctx context.Context) context.Context {
-	// Override the default API url with APTIBLE_API_ROOT_URL, if non-empty
-	if url := os.Getenv("APTIBLE_API_ROOT_URL"); url != "" {
-		ctx = context.WithValue(ctx, aptibleapi.ContextServerVariables, map[string]string{"url": url})
-	}
-
-	if m.Token == "" {
-		log.Fatalln("Could not read token: Please run aptible login or set APTIBLE_ACCESS_TOKEN")
-		return ctx
-	}
-
+	// A missing token is reported when the provider is configured, and
+	// expired tokens are refreshed by the token source when possible.
 	return context.WithValue(ctx, aptibleapi.ContextAPIKeys, map[string]aptibleapi.APIKey{
 		"token": {
 			Prefix: "Bearer",
-			Key:    m.Token,
+			Key:    m.tokens.Token(),
 		},
 	})
 }
@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/aptible/aptible-api-go/aptibleapi"
 	"github.com/aptible/go-deploy/aptible"
@@ -21,10 +22,15 @@ func resourceApp() *schema.Resource {
 		CreateContext: resourceAppCreate, // POST
 		ReadContext:   resourceAppRead,   // GET
 		UpdateContext: resourceAppUpdate, // PUT
-		Delete:        resourceAppDelete, // DELETE
+		DeleteContext: resourceAppDelete, // DELETE
 		Importer: &schema.ResourceImporter{
 			State: resourceAppImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"env_id": {
@@ -43,6 +49,18 @@ func resourceApp() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"restart_triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"restart_on_handle_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"app_id": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -346,7 +364,6 @@ func validatePrivateRegistrySettings(_ context.Context, d *schema.ResourceDiff,
 func resourceAppCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	m := meta.(*providerMetadata)
 	client := m.Client
-	legacy := m.LegacyClient
 	envID := int32(d.Get("env_id").(int))
 	ctx = m.APIContext(ctx)
 	diags := diag.Diagnostics{}
@@ -429,7 +446,7 @@ func resourceAppCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 			})
 		}
 
-		_, err = legacy.WaitForOperation(int64(operation.Id))
+		_, err = waitForOperation(ctx, meta, operation.Id)
 		if err != nil {
 			// Do not return here so that the read method can hydrate the state
 			diags = append(diags, diag.Diagnostic{
@@ -466,6 +483,7 @@ func resourceAppCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 func resourceAppImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	appID, _ := strconv.Atoi(d.Id())
 	_ = d.Set("app_id", appID)
+	_ = d.Set("restart_on_handle_change", false)
 	if err := diagnosticsToError(resourceAppRead(context.Background(), d, meta)); err != nil {
 		return nil, err
 	}
@@ -663,7 +681,7 @@ func resourceAppUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 				Detail:   err.Error(),
 			})
 		}
-		_, err = legacy.WaitForOperation(int64(operation.Id))
+		_, err = waitForOperation(ctx, meta, operation.Id)
 		if err != nil {
 			// Do not return here so that the read method can hydrate the state
 			diags = append(diags, diag.Diagnostic{
@@ -709,9 +727,24 @@ func resourceAppUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 			})
 			return diags
 		}
+	}
+
+	restartOnHandleChange := d.HasChange("handle") && d.Get("restart_on_handle_change").(bool)
+	// A deploy or configure restarts the app, but it ran before the handle
+	// changed so only a trigger change can be skipped
+	if restartOnHandleChange || (operationType == "none" && d.HasChange("restart_triggers")) {
+		if err := restartApp(ctx, meta, appID); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("There was an error when trying to restart the app with handle: %s", handle),
+				Detail:   err.Error(),
+			})
+			return append(diags, resourceAppRead(ctx, d, meta)...)
+		}
+	} else if d.HasChange("handle") {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
-			Summary:  fmt.Sprintf("You must restart the app to see changes. In order for the new app name (%s) to appear in log drain and metric drain destinations, you must restart the app. You can use the CLI to do this with: 'aptible restart --app=%s'.", handle, handle),
+			Summary:  fmt.Sprintf("You must restart the app to see changes. In order for the new app name (%s) to appear in log drain and metric drain destinations, you must restart the app. You can use the CLI to do this with: 'aptible restart --app=%s', or set restart_on_handle_change to have Terraform restart it.", handle, handle),
 		})
 		log.Printf("[WARN] In order for the new app name (%s) to appear in log drain and metric drain destinations, you must restart the app.\n", handle)
 	}
@@ -720,22 +753,51 @@ func resourceAppUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 	return diags
 }
 
-func resourceAppDelete(d *schema.ResourceData, meta interface{}) error {
-	readDiags := resourceAppRead(context.Background(), d, meta)
-	if readDiags.HasError() {
-		return diagnosticsToError(readDiags)
+// restartApp restarts all of the app's services and waits for them to come back
+func restartApp(ctx context.Context, meta interface{}, appID int32) error {
+	client := meta.(*providerMetadata).Client
+
+	log.Printf("Restarting app with ID: %d\n", appID)
+
+	op, _, err := client.OperationsAPI.
+		CreateOperationForApp(ctx, appID).
+		CreateOperationRequest(*aptibleapi.NewCreateOperationRequest("restart")).
+		Execute()
+	if err != nil {
+		return err
 	}
+	_, err = waitForOperation(ctx, meta, op.Id)
+	return err
+}
 
-	appID := int64(d.Get("app_id").(int))
-	client := meta.(*providerMetadata).LegacyClient
-	deleted, err := client.DeleteApp(appID)
-	if deleted {
-		d.SetId("")
-		return nil
+func resourceAppDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	readDiags := resourceAppRead(ctx, d, meta)
+	if readDiags.HasError() {
+		return readDiags
 	}
+
+	m := meta.(*providerMetadata)
+	appID := int32(d.Get("app_id").(int))
+	operation, _, err := m.Client.OperationsAPI.
+		CreateOperationForApp(m.APIContext(ctx), appID).
+		CreateOperationRequest(*aptibleapi.NewCreateOperationRequest("deprovision")).
+		Execute()
 	if err != nil {
 		log.Println("There was an error when completing the request to destroy the app.\n[ERROR] -", err)
-		return generateErrorFromClientError(err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to create deprovision operation for app %d", appID),
+			Detail:   err.Error(),
+		}}
+	}
+
+	if _, err := waitForOperation(ctx, meta, operation.Id); err != nil {
+		log.Println("There was an error when completing the request to destroy the app.\n[ERROR] -", err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to deprovision app %d", appID),
+			Detail:   err.Error(),
+		}}
 	}
 
 	d.SetId("")
@@ -838,7 +900,6 @@ func updateServices(ctx context.Context, d *schema.ResourceData, meta interface{
 
 func scaleServices(c context.Context, d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*providerMetadata).Client
-	legacy := meta.(*providerMetadata).LegacyClient
 	appID := int32(d.Get("app_id").(int))
 	ctx := meta.(*providerMetadata).APIContext(c)
 
@@ -913,7 +974,7 @@ func scaleServices(c context.Context, d *schema.ResourceData, meta interface{})
 				return err
 			}
 
-			_, err = legacy.WaitForOperation(int64(resp.Id))
+			_, err = waitForOperation(ctx, meta, resp.Id)
 			return err
 		})
 	}
@@ -652,6 +652,39 @@ func TestAccResourceApp_updateRestartFreeScaling(t *testing.T) {
 	})
 }
 
+func TestAccResourceApp_restartTriggers(t *testing.T) {
+	rHandle := acctest.RandString(10)
+	appHandle := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAppDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAptibleAppRestartTriggers(rHandle, appHandle, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aptible_app.test", "handle", appHandle),
+					resource.TestCheckResourceAttr("aptible_app.test", "restart_triggers.deployed_at", "1"),
+					resource.TestCheckResourceAttr("aptible_app.test", "restart_on_handle_change", "true"),
+				),
+			},
+			{
+				Config: testAccAptibleAppRestartTriggers(rHandle, appHandle, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aptible_app.test", "restart_triggers.deployed_at", "2"),
+				),
+			},
+			{
+				Config: testAccAptibleAppRestartTriggers(rHandle, appHandle+"-renamed", "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aptible_app.test", "handle", appHandle+"-renamed"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckAppDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*providerMetadata).LegacyClient
 	for _, rs := range s.RootModule().Resources {
@@ -721,6 +754,31 @@ func testAccAptibleAppDeploy(handle string, index string) string {
 	`, handle, testOrganizationId, testStackId, handle, index)
 }
 
+func testAccAptibleAppRestartTriggers(envHandle string, appHandle string, trigger string) string {
+	return fmt.Sprintf(`
+	resource "aptible_environment" "test" {
+		handle = "%s"
+		org_id = "%s"
+		stack_id = "%v"
+	}
+
+	resource "aptible_app" "test" {
+		env_id = aptible_environment.test.env_id
+		handle = "%v"
+		docker_image = "quay.io/aptible/nginx-mirror:1"
+		restart_on_handle_change = true
+		restart_triggers = {
+			deployed_at = "%s"
+		}
+		service {
+			process_type = "cmd"
+			container_memory_limit = 512
+			container_count = 1
+		}
+	}
+	`, envHandle, testOrganizationId, testStackId, appHandle, trigger)
+}
+
 func testAccAptibleAppDeployStopTimeout(handle string, index string) string {
 	return fmt.Sprintf(`
 	resource "aptible_environment" "test" {
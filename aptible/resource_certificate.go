@@ -0,0 +1,185 @@
+package aptible
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCertificate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCertificateCreate,
+		ReadContext:   resourceCertificateRead,
+		DeleteContext: resourceCertificateDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCertificateImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"env_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"certificate_body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressEquivalentPEM,
+			},
+			"private_key": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressEquivalentPEM,
+			},
+			"certificate_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"common_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"subject_alternative_names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"not_before": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"not_after": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"issuer_common_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"sha256_fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"trusted": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"self_signed": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCertificateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	client := m.Client
+	ctx = m.APIContext(ctx)
+	envID := int32(d.Get("env_id").(int))
+
+	certificate, _, err := client.CertificatesAPI.
+		CreateCertificate(ctx, envID).
+		CreateCertificateRequest(*aptibleapi.NewCreateCertificateRequest(
+			d.Get("certificate_body").(string),
+			d.Get("private_key").(string),
+		)).
+		Execute()
+	if err != nil {
+		log.Println(err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to create certificate in environment %d", envID),
+			Detail:   err.Error(),
+		}}
+	}
+
+	_ = d.Set("certificate_id", int(certificate.Id))
+	d.SetId(strconv.Itoa(int(certificate.Id)))
+
+	return resourceCertificateRead(ctx, d, meta)
+}
+
+func resourceCertificateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	client := m.Client
+	ctx = m.APIContext(ctx)
+	certificateID := int32(d.Get("certificate_id").(int))
+
+	log.Println("Getting certificate with ID: " + strconv.Itoa(int(certificateID)))
+
+	certificate, resp, err := client.CertificatesAPI.GetCertificate(ctx, certificateID).Execute()
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		d.SetId("")
+		log.Printf("Certificate with ID: %d was deleted outside of Terraform. Removing it from Terraform state.", certificateID)
+		return nil
+	}
+	if err != nil {
+		log.Println(err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error getting certificate with ID: %d", certificateID),
+			Detail:   err.Error(),
+		}}
+	}
+
+	_ = d.Set("certificate_id", int(certificate.Id))
+	// Differences in PEM formatting are suppressed by the schema
+	if certificate.CertificateBody != "" {
+		_ = d.Set("certificate_body", certificate.CertificateBody)
+	}
+	if certificate.PrivateKey != "" {
+		_ = d.Set("private_key", certificate.PrivateKey)
+	}
+	_ = d.Set("env_id", ExtractIdFromLink(certificate.Links.Account.GetHref()))
+	_ = d.Set("common_name", certificate.CommonName)
+	_ = d.Set("subject_alternative_names", certificate.SubjectAlternativeNames)
+	_ = d.Set("not_before", certificate.NotBefore)
+	_ = d.Set("not_after", certificate.NotAfter)
+	_ = d.Set("issuer_common_name", certificate.IssuerCommonName)
+	_ = d.Set("sha256_fingerprint", certificate.Sha256Fingerprint)
+	_ = d.Set("trusted", certificate.Trusted)
+	_ = d.Set("self_signed", certificate.SelfSigned)
+
+	return nil
+}
+
+func resourceCertificateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	certificateID := int32(d.Get("certificate_id").(int))
+
+	resp, err := m.Client.CertificatesAPI.DeleteCertificate(m.APIContext(ctx), certificateID).Execute()
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		log.Println(err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to delete certificate %d", certificateID),
+			Detail:   fmt.Sprintf("%s\n\nCertificates can't be deleted while an endpoint uses them.", err),
+		}}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceCertificateImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	certificateID, _ := strconv.Atoi(d.Id())
+	_ = d.Set("certificate_id", certificateID)
+	diags := resourceCertificateRead(ctx, d, meta)
+	return []*schema.ResourceData{d}, diagnosticsToError(diags)
+}
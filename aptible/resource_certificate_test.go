@@ -0,0 +1,217 @@
+package aptible
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceCertificate_basic(t *testing.T) {
+	rHandle := acctest.RandString(10)
+	domain := fmt.Sprintf("%s.aptible-test-demo.pizza", rHandle)
+	cert, key := testAccSelfSignedCertificate(t, domain)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckCertificateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAptibleCertificate(rHandle, cert, key),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("aptible_environment.test", "env_id", "aptible_certificate.test", "env_id"),
+					resource.TestCheckResourceAttrSet("aptible_certificate.test", "certificate_id"),
+					resource.TestCheckResourceAttr("aptible_certificate.test", "common_name", domain),
+					resource.TestCheckResourceAttr("aptible_certificate.test", "subject_alternative_names.#", "1"),
+					resource.TestCheckResourceAttr("aptible_certificate.test", "subject_alternative_names.0", domain),
+					resource.TestCheckResourceAttr("aptible_certificate.test", "self_signed", "true"),
+					resource.TestCheckResourceAttrSet("aptible_certificate.test", "not_after"),
+					resource.TestCheckResourceAttrSet("aptible_certificate.test", "sha256_fingerprint"),
+				),
+			},
+			{
+				ResourceName:      "aptible_certificate.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The PEM data is read back, but may be formatted differently
+				ImportStateVerifyIgnore: []string{"certificate_body", "private_key"},
+			},
+		},
+	})
+}
+
+func TestAccResourceCertificate_endpointRotation(t *testing.T) {
+	appHandle := acctest.RandString(10)
+	domain := fmt.Sprintf("%s.aptible-test-demo.pizza", appHandle)
+	cert, key := testAccSelfSignedCertificate(t, domain)
+	rotatedCert, rotatedKey := testAccSelfSignedCertificate(t, domain)
+	var endpointID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckCertificateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAptibleEndpointWithCertificate(appHandle, domain, cert, key),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("aptible_certificate.test", "certificate_id", "aptible_endpoint.test", "certificate_id"),
+					resource.TestCheckResourceAttr("aptible_endpoint.test", "managed", "false"),
+					func(s *terraform.State) error {
+						endpointID = s.RootModule().Resources["aptible_endpoint.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// The endpoint is updated in place to use the new certificate
+				Config: testAccAptibleEndpointWithCertificate(appHandle, domain, rotatedCert, rotatedKey),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("aptible_certificate.test", "certificate_id", "aptible_endpoint.test", "certificate_id"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources["aptible_endpoint.test"].Primary.ID; id != endpointID {
+							return fmt.Errorf("expected endpoint %s to be updated in place, got %s", endpointID, id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCertificateDestroy(s *terraform.State) error {
+	m := testAccProvider.Meta().(*providerMetadata)
+	ctx := m.APIContext(context.Background())
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aptible_certificate" {
+			continue
+		}
+
+		certificateID, err := strconv.Atoi(rs.Primary.Attributes["certificate_id"])
+		if err != nil {
+			return err
+		}
+
+		_, resp, err := m.Client.CertificatesAPI.GetCertificate(ctx, int32(certificateID)).Execute()
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("certificate %v not removed", certificateID)
+	}
+	return nil
+}
+
+// testAccSelfSignedCertificate returns a PEM encoded certificate and private
+// key for the domain
+func testAccSelfSignedCertificate(t *testing.T, domain string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: domain},
+		DNSNames:     []string{domain},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	return string(cert), string(keyPem)
+}
+
+func testAccAptibleCertificate(handle string, cert string, key string) string {
+	return fmt.Sprintf(`
+	resource "aptible_environment" "test" {
+		handle = "%s"
+		org_id = "%s"
+		stack_id = "%v"
+	}
+
+	resource "aptible_certificate" "test" {
+		env_id = aptible_environment.test.env_id
+		certificate_body = <<EOT
+%sEOT
+		private_key = <<EOT
+%sEOT
+	}
+`, handle, testOrganizationId, testStackId, cert, key)
+}
+
+func testAccAptibleEndpointWithCertificate(appHandle string, domain string, cert string, key string) string {
+	return fmt.Sprintf(`
+	resource "aptible_environment" "test" {
+		handle = "%s"
+		org_id = "%s"
+		stack_id = "%v"
+	}
+
+	resource "aptible_app" "test" {
+		env_id = aptible_environment.test.env_id
+		handle = "%v"
+		docker_image = "quay.io/aptible/nginx-mirror:31"
+		service {
+			process_type = "cmd"
+			container_memory_limit = 512
+			container_count = 1
+		}
+	}
+
+	resource "aptible_certificate" "test" {
+		env_id = aptible_environment.test.env_id
+		certificate_body = <<EOT
+%sEOT
+		private_key = <<EOT
+%sEOT
+
+		lifecycle {
+			create_before_destroy = true
+		}
+	}
+
+	resource "aptible_endpoint" "test" {
+		env_id = aptible_environment.test.env_id
+		resource_id = aptible_app.test.app_id
+		resource_type = "app"
+		process_type = "cmd"
+		endpoint_type = "https"
+		domain = "%s"
+		certificate_id = aptible_certificate.test.certificate_id
+		platform = "alb"
+	}
+`, appHandle, testOrganizationId, testStackId, appHandle, cert, key, domain)
+}
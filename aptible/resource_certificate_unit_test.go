@@ -0,0 +1,26 @@
+package aptible
+
+import "testing"
+
+func TestSuppressEquivalentPEM(t *testing.T) {
+	const pem = "-----BEGIN CERTIFICATE-----\nMIIB\nAAAA\n-----END CERTIFICATE-----\n"
+
+	tests := []struct {
+		name string
+		new  string
+		want bool
+	}{
+		{name: "identical", new: pem, want: true},
+		{name: "CRLF line endings", new: "-----BEGIN CERTIFICATE-----\r\nMIIB\r\nAAAA\r\n-----END CERTIFICATE-----\r\n", want: true},
+		{name: "surrounding whitespace", new: "\n  " + pem + "\n\n", want: true},
+		{name: "different contents", new: "-----BEGIN CERTIFICATE-----\nMIIC\nAAAA\n-----END CERTIFICATE-----\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suppressEquivalentPEM("certificate_body", pem, tt.new, nil); got != tt.want {
+				t.Errorf("suppressEquivalentPEM() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
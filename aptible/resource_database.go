@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aptible/aptible-api-go/aptibleapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -20,10 +24,16 @@ func resourceDatabase() *schema.Resource {
 		CreateContext: resourceDatabaseCreate, // POST
 		Read:          resourceDatabaseRead,   // GET
 		UpdateContext: resourceDatabaseUpdate, // PUT
-		Delete:        resourceDatabaseDelete, // DELETE
+		DeleteContext: resourceDatabaseDelete, // DELETE
+		CustomizeDiff: resourceDatabaseValidate,
 		Importer: &schema.ResourceImporter{
 			State: resourceDatabaseImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+			Update: schema.DefaultTimeout(120 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"env_id": {
@@ -92,18 +102,170 @@ func resourceDatabase() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"parameters": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validateDatabaseParameters,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"credentials": databaseCredentialsSchema(),
+			"credentials_rotation": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"restart_triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"restart_on_handle_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"enable_backups": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  true,
 			},
+			"restore_from_backup_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+			"upgrade_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(validUpgradeStrategies, false),
+			},
 		},
 	}
 }
 
+// databaseCredentialsSchema is the structured form of the database's
+// credentials, shared by the resource and data source
+func databaseCredentialsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:      schema.TypeList,
+		Computed:  true,
+		Sensitive: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"credential_id": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"type": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"default": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"connection_url": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"host": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"port": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"username": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"password": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// flattenDatabaseCredential splits the credential's connection URL into its
+// parts. The parts are left empty when the URL can't be parsed so that the
+// connection URL itself is still available.
+func flattenDatabaseCredential(cred aptibleapi.DatabaseCredential) map[string]interface{} {
+	flat := map[string]interface{}{
+		"credential_id":  int(cred.Id),
+		"type":           cred.Type,
+		"default":        cred.Default,
+		"connection_url": cred.ConnectionUrl,
+		"host":           "",
+		"port":           0,
+		"username":       "",
+		"password":       "",
+	}
+
+	u, err := url.Parse(cred.ConnectionUrl)
+	if err != nil {
+		log.Printf("[WARN] Unable to parse the connection URL of database credential %d: %s\n", cred.Id, err)
+		return flat
+	}
+	flat["host"] = u.Hostname()
+	if port, err := strconv.Atoi(u.Port()); err == nil {
+		flat["port"] = port
+	}
+	if u.User != nil {
+		flat["username"] = u.User.Username()
+		flat["password"], _ = u.User.Password()
+	}
+	return flat
+}
+
+func resourceDatabaseValidate(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// Rotating changes every connection URL, so don't plan the current ones
+	if d.Id() != "" && d.HasChange("credentials_rotation") {
+		for _, key := range []string{"credentials", "connection_urls", "default_connection_url"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Catch versions that don't exist for the database type at plan time
+	// rather than after the database's handle has been reserved
+	version := d.Get("version").(string)
+	if version != "" && d.NewValueKnown("version") && (d.Id() == "" || d.HasChange("version")) {
+		ctx = meta.(*providerMetadata).APIContext(ctx)
+		images, err := listDatabaseImages(ctx, meta)
+		if err != nil {
+			return fmt.Errorf("unable to list database images to validate version %q: %w", version, err)
+		}
+		if _, err := findDatabaseImage(images, d.Get("database_type").(string), version); err != nil {
+			return err
+		}
+	}
+
+	// Only existing databases are upgraded, new ones are created with the
+	// configured version
+	if d.Id() == "" || !d.HasChange("version") || version == "" {
+		return nil
+	}
+
+	strategy := d.Get("upgrade_strategy").(string)
+	databaseType := d.Get("database_type").(string)
+	if strategy == "" {
+		return fmt.Errorf("version can only be changed when upgrade_strategy is set to one of %q", validUpgradeStrategies)
+	}
+	if strategy == "pg_upgrade" && databaseType != "postgresql" {
+		return fmt.Errorf("upgrade_strategy %q is only supported for postgresql databases", strategy)
+	}
+	return nil
+}
+
 func resourceDatabaseCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	m := meta.(*providerMetadata)
-	legacy := m.LegacyClient
 	client := m.Client
 	ctx = m.APIContext(ctx)
 	diags := diag.Diagnostics{}
@@ -118,6 +280,10 @@ func resourceDatabaseCreate(ctx context.Context, d *schema.ResourceData, meta in
 	containerSize := int32(d.Get("container_size").(int))
 	enableBackups := d.Get("enable_backups").(bool)
 
+	if _, ok := d.GetOk("restore_from_backup_id"); ok {
+		return resourceDatabaseRestore(ctx, d, meta)
+	}
+
 	create := aptibleapi.NewCreateDatabaseRequest(handle, databaseType)
 
 	if diskSize != 0 {
@@ -131,12 +297,23 @@ func resourceDatabaseCreate(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	if version != "" {
-		image, err := legacy.GetDatabaseImageByTypeAndVersion(databaseType, version)
+		images, err := listDatabaseImages(ctx, meta)
 		if err != nil {
-			log.Println(err)
-			return generateDiagnosticsFromClientError(err)
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error listing database images",
+				Detail:   err.Error(),
+			})
 		}
-		create.SetDatabaseImageId(int32(image.ID))
+		image, err := findDatabaseImage(images, databaseType, version)
+		if err != nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error finding the image for %s %s", databaseType, version),
+				Detail:   err.Error(),
+			})
+		}
+		create.SetDatabaseImageId(image.Id)
 	}
 
 	db, _, err := client.DatabasesAPI.
@@ -180,7 +357,7 @@ func resourceDatabaseCreate(ctx context.Context, d *schema.ResourceData, meta in
 			Detail:   err.Error(),
 		})
 	} else {
-		_, err = legacy.WaitForOperation(int64(op.Id))
+		_, err = waitForOperation(ctx, meta, op.Id)
 		if err != nil {
 			// Do not return so that the read method can hydrate the state
 			diags = append(diags, diag.Diagnostic{
@@ -191,6 +368,159 @@ func resourceDatabaseCreate(ctx context.Context, d *schema.ResourceData, meta in
 		}
 	}
 
+	if !diags.HasError() {
+		diags = append(diags, applyDatabaseParameters(ctx, d, meta, db.Id)...)
+	}
+
+	return append(diags, diag.FromErr(resourceDatabaseRead(d, meta))...)
+}
+
+// resourceDatabaseRestore creates the database by restoring a backup instead of
+// provisioning an empty one. The restore operation creates the database, so
+// settings it doesn't take are applied once it completes.
+func resourceDatabaseRestore(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMetadata).Client
+	diags := diag.Diagnostics{}
+
+	backupID := int32(d.Get("restore_from_backup_id").(int))
+	envID := int32(d.Get("env_id").(int))
+	handle := d.Get("handle").(string)
+	version := d.Get("version").(string)
+	databaseType := d.Get("database_type").(string)
+	iops := int32(d.Get("iops").(int))
+	profile := d.Get("container_profile").(string)
+	diskSize := int32(d.Get("disk_size").(int))
+	containerSize := int32(d.Get("container_size").(int))
+	enableBackups := d.Get("enable_backups").(bool)
+
+	backup, resp, err := client.BackupsAPI.GetBackup(ctx, backupID).Execute()
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Backup with ID: %d not found", backupID),
+			Detail:   "restore_from_backup_id must be the ID of an existing backup",
+		})
+	}
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error getting backup with ID: %d", backupID),
+			Detail:   err.Error(),
+		})
+	}
+
+	// The restored database runs the backup's image, so a different type or
+	// version in the configuration would replace it on the next plan
+	imageID := ExtractIdFromLink(backup.GetLinks().DatabaseImage.GetHref())
+	image, _, err := client.ImagesAPI.GetDatabaseImage(ctx, imageID).Execute()
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error getting the database image of backup with ID: %d", backupID),
+			Detail:   err.Error(),
+		})
+	}
+	if image.Type != databaseType || (version != "" && image.Version != version) {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Validation Error",
+			Detail: fmt.Sprintf(
+				"Backup %d is of a %s %s database, database_type and version must match it",
+				backupID, image.Type, image.Version,
+			),
+		})
+	}
+
+	payload := aptibleapi.NewCreateOperationRequest("restore")
+	payload.SetHandle(handle)
+	payload.SetDestinationAccountId(envID)
+	if diskSize != 0 {
+		payload.SetDiskSize(diskSize)
+	}
+	if containerSize != 0 {
+		payload.SetContainerSize(containerSize)
+	}
+	if profile != "" {
+		payload.SetInstanceProfile(profile)
+	}
+
+	op, _, err := client.OperationsAPI.
+		CreateOperationForBackup(ctx, backupID).
+		CreateOperationRequest(*payload).
+		Execute()
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error creating restore operation for backup with ID: %d", backupID),
+			Detail:   err.Error(),
+		})
+	}
+	if _, err := waitForOperation(ctx, meta, op.Id); err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to restore backup with ID: %d", backupID),
+			Detail:   err.Error(),
+		})
+	}
+
+	// The restore operation's resource is the database it created
+	restore, _, err := client.OperationsAPI.GetOperation(ctx, op.Id).Execute()
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error getting restore operation %d", op.Id),
+			Detail:   err.Error(),
+		})
+	}
+	databaseID := ExtractIdFromLink(restore.GetLinks().Resource.GetHref())
+	if databaseID == 0 {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Could not find the database restored by operation %d", op.Id),
+		})
+	}
+
+	_ = d.Set("database_id", databaseID)
+	d.SetId(strconv.Itoa(int(databaseID)))
+
+	if !enableBackups {
+		_, err := client.DatabasesAPI.
+			PatchDatabase(ctx, databaseID).
+			UpdateDatabaseRequest(aptibleapi.UpdateDatabaseRequest{EnableBackups: &enableBackups}).
+			Execute()
+		if err != nil {
+			// Do not return here so that the read method can hydrate the state
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "There was an error when trying to set enable_backups.",
+				Detail:   err.Error(),
+			})
+		}
+	}
+
+	database, _, err := client.DatabasesAPI.GetDatabase(ctx, databaseID).Execute()
+	if err == nil && iops != 0 && database.Embedded.Disk.GetProvisionedIops() != iops {
+		payload := aptibleapi.NewCreateOperationRequest("restart")
+		payload.SetProvisionedIops(iops)
+		op, _, err = client.OperationsAPI.
+			CreateOperationForDatabase(ctx, databaseID).
+			CreateOperationRequest(*payload).
+			Execute()
+		if err == nil {
+			_, err = waitForOperation(ctx, meta, op.Id)
+		}
+	}
+	if err != nil {
+		// Do not return here so that the read method can hydrate the state
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to set the iops of restored database with handle: %s", handle),
+			Detail:   err.Error(),
+		})
+	}
+
+	diags = append(diags, applyDatabaseParameters(ctx, d, meta, databaseID)...)
+
 	return append(diags, diag.FromErr(resourceDatabaseRead(d, meta))...)
 }
 
@@ -212,9 +542,11 @@ func resourceDatabaseRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	urls := []string{}
+	credentials := []map[string]interface{}{}
 	creds := database.Embedded.GetDatabaseCredentials()
 	for _, cred := range creds {
 		urls = append(urls, cred.ConnectionUrl)
+		credentials = append(credentials, flattenDatabaseCredential(cred))
 	}
 
 	imageID := ExtractIdFromLink(database.Links.DatabaseImage.GetHref())
@@ -230,6 +562,15 @@ func resourceDatabaseRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Could not find database account ID")
 	}
 
+	parameters := map[string]string{}
+	if currConfID := ExtractIdFromLink(database.Links.CurrentConfiguration.GetHref()); currConfID != 0 {
+		currConf, _, err := client.ConfigurationsAPI.GetConfiguration(ctx, currConfID).Execute()
+		if err != nil {
+			return err
+		}
+		parameters = managedDatabaseParameters(currConf.Env, d.Get("parameters").(map[string]interface{}))
+	}
+
 	image, _, err := client.ImagesAPI.GetDatabaseImage(ctx, imageID).Execute()
 	if err != nil {
 		return err
@@ -249,6 +590,8 @@ func resourceDatabaseRead(d *schema.ResourceData, meta interface{}) error {
 	_ = d.Set("disk_size", database.Embedded.Disk.GetSize())
 	_ = d.Set("default_connection_url", database.GetConnectionUrl())
 	_ = d.Set("connection_urls", urls)
+	_ = d.Set("credentials", credentials)
+	_ = d.Set("parameters", parameters)
 	_ = d.Set("handle", database.GetHandle())
 	_ = d.Set("env_id", accountID)
 	_ = d.Set("database_type", database.Type.Get())
@@ -262,6 +605,7 @@ func resourceDatabaseRead(d *schema.ResourceData, meta interface{}) error {
 func resourceDatabaseImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	databaseID, _ := strconv.Atoi(d.Id())
 	_ = d.Set("database_id", databaseID)
+	_ = d.Set("restart_on_handle_change", false)
 	err := resourceDatabaseRead(d, meta)
 	return []*schema.ResourceData{d}, err
 }
@@ -269,7 +613,6 @@ func resourceDatabaseImport(d *schema.ResourceData, meta interface{}) ([]*schema
 // changes state of actual resource based on changes made in a Terraform config file
 func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*providerMetadata).Client
-	legacy := meta.(*providerMetadata).LegacyClient
 	databaseID := int32(d.Get("database_id").(int))
 	containerSize := int32(d.Get("container_size").(int))
 	profile := d.Get("container_profile").(string)
@@ -281,6 +624,22 @@ func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	var diags diag.Diagnostics
 
 	ctx = meta.(*providerMetadata).APIContext(ctx)
+
+	// Upgrade first so that any resize restarts the database on its new version
+	if d.HasChange("version") && d.Get("version").(string) != "" {
+		databaseType := d.Get("database_type").(string)
+		version := d.Get("version").(string)
+		strategy := d.Get("upgrade_strategy").(string)
+		if err := upgradeDatabase(ctx, meta, databaseID, databaseType, version, strategy); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("There was an error when trying to upgrade the database to version %s.", version),
+				Detail:   err.Error(),
+			})
+			return append(diags, diag.FromErr(resourceDatabaseRead(d, meta))...)
+		}
+	}
+
 	payload := aptibleapi.NewCreateOperationRequest("restart")
 
 	if d.HasChange("container_size") {
@@ -335,6 +694,31 @@ func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		}
 	}
 
+	if d.HasChange("parameters") {
+		if needsOperation {
+			// The resize restart applies the parameters too, so the database
+			// only restarts once
+			env, _ := databaseParametersEnv(d)
+			payload.SetEnv(env)
+		} else {
+			diags = append(diags, applyDatabaseParameters(ctx, d, meta, databaseID)...)
+			if diags.HasError() {
+				return append(diags, diag.FromErr(resourceDatabaseRead(d, meta))...)
+			}
+		}
+	}
+
+	if d.HasChange("credentials_rotation") {
+		if err := rotateDatabaseCredentials(ctx, meta, databaseID); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "There was an error when trying to rotate the database credentials.",
+				Detail:   err.Error(),
+			})
+			return append(diags, diag.FromErr(resourceDatabaseRead(d, meta))...)
+		}
+	}
+
 	if needsOperation {
 		op, _, err := client.
 			OperationsAPI.
@@ -349,12 +733,12 @@ func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, meta in
 			return diags
 		}
 
-		del, err := legacy.WaitForOperation(int64(op.Id))
+		del, err := waitForOperation(ctx, meta, op.Id)
 		if err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  "There was an error when trying to update the database.",
-				Detail:   generateErrorFromClientError(err).Error(),
+				Detail:   err.Error(),
 			})
 			return diags
 		}
@@ -368,10 +752,30 @@ func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		}
 	}
 
-	if d.HasChange("handle") {
+	restartOnHandleChange := d.HasChange("handle") && d.Get("restart_on_handle_change").(bool)
+	// A restart, for a resize or the triggers, also picks up the new handle
+	if !needsOperation && d.HasChange("restart_triggers") {
+		if err := restartDatabase(ctx, meta, databaseID); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("There was an error when trying to restart the database with handle: %s", handle),
+				Detail:   err.Error(),
+			})
+			return append(diags, diag.FromErr(resourceDatabaseRead(d, meta))...)
+		}
+	} else if !needsOperation && restartOnHandleChange {
+		if err := reloadDatabase(ctx, meta, databaseID); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("There was an error when trying to reload the database with handle: %s", handle),
+				Detail:   err.Error(),
+			})
+			return append(diags, diag.FromErr(resourceDatabaseRead(d, meta))...)
+		}
+	} else if d.HasChange("handle") && !needsOperation {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
-			Summary:  fmt.Sprintf("You must reload the database to see changes. In order for the new database name (%s) to appear in log drain and metric drain destinations, you must reload the database.  You can use the CLI to do this with: 'aptible db:reload %s'", handle, handle),
+			Summary:  fmt.Sprintf("You must reload the database to see changes. In order for the new database name (%s) to appear in log drain and metric drain destinations, you must reload the database.  You can use the CLI to do this with: 'aptible db:reload %s', or set restart_on_handle_change to have Terraform reload it", handle, handle),
 		})
 		log.Printf("[WARN] In order for the new database name (%s) to appear in log drain and metric drain destinations, you must restart the database.\n", handle)
 	}
@@ -387,20 +791,237 @@ func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	return diags
 }
 
-func resourceDatabaseDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*providerMetadata).LegacyClient
-	databaseID := int64(d.Get("database_id").(int))
+// applyDatabaseParameters configures the database with its parameters and
+// reloads it so that they take effect. Parameters removed from the config are
+// sent as empty values, which resets them to the database's defaults.
+func applyDatabaseParameters(ctx context.Context, d *schema.ResourceData, meta interface{}, databaseID int32) diag.Diagnostics {
+	client := meta.(*providerMetadata).Client
+	env, keys := databaseParametersEnv(d)
+	if len(env) == 0 {
+		return nil
+	}
+
+	for _, operationType := range []string{"configure", "reload"} {
+		payload := aptibleapi.NewCreateOperationRequest(operationType)
+		if operationType == "configure" {
+			payload.SetEnv(env)
+		}
+
+		op, _, err := client.OperationsAPI.
+			CreateOperationForDatabase(ctx, databaseID).
+			CreateOperationRequest(*payload).
+			Execute()
+		if err == nil {
+			_, err = waitForOperation(ctx, meta, op.Id)
+		}
+		if err != nil {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Failed to apply the database parameters: %s", strings.Join(keys, ", ")),
+				Detail: fmt.Sprintf(
+					"The %s operation failed. Check that each parameter is supported by the %s database and that its value is valid.\n\n%s",
+					operationType, d.Get("database_type").(string), err,
+				),
+			}}
+		}
+	}
+	return nil
+}
+
+// databaseParametersEnv returns the environment that moves the database from
+// its old parameters to its new ones, along with the sorted parameter names.
+// Removed parameters are configured as empty values.
+func databaseParametersEnv(d *schema.ResourceData) (map[string]string, []string) {
+	oldRaw, newRaw := d.GetChange("parameters")
+
+	env := map[string]string{}
+	for k := range oldRaw.(map[string]interface{}) {
+		env[k] = ""
+	}
+	for k, v := range newRaw.(map[string]interface{}) {
+		env[k] = v.(string)
+	}
+
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return env, keys
+}
+
+// managedDatabaseParameters returns the parameters Terraform manages from the
+// database's configuration. The configuration holds more than the parameters,
+// so only the keys already in config or state are read back.
+func managedDatabaseParameters(env map[string]interface{}, managed map[string]interface{}) map[string]string {
+	parameters := map[string]string{}
+	for k := range managed {
+		// Removed parameters are configured as empty values
+		if v, ok := env[k]; ok && v != nil && v != "" {
+			parameters[k] = fmt.Sprint(v)
+		}
+	}
+	return parameters
+}
+
+// reloadDatabase reloads the database, e.g. to pick up a new handle, and waits
+// for it to come back
+func reloadDatabase(ctx context.Context, meta interface{}, databaseID int32) error {
+	client := meta.(*providerMetadata).Client
 
-	err := client.DeleteDatabase(databaseID)
+	log.Printf("Reloading database with ID: %d\n", databaseID)
+
+	op, _, err := client.OperationsAPI.
+		CreateOperationForDatabase(ctx, databaseID).
+		CreateOperationRequest(*aptibleapi.NewCreateOperationRequest("reload")).
+		Execute()
 	if err != nil {
-		log.Println(err)
-		return generateErrorFromClientError(err)
+		return err
+	}
+	_, err = waitForOperation(ctx, meta, op.Id)
+	return err
+}
+
+// restartDatabase restarts the database without changing its size and waits
+// for it to come back. Used for both databases and replicas.
+func restartDatabase(ctx context.Context, meta interface{}, databaseID int32) error {
+	client := meta.(*providerMetadata).Client
+
+	log.Printf("Restarting database with ID: %d\n", databaseID)
+
+	op, _, err := client.OperationsAPI.
+		CreateOperationForDatabase(ctx, databaseID).
+		CreateOperationRequest(*aptibleapi.NewCreateOperationRequest("restart")).
+		Execute()
+	if err != nil {
+		return err
+	}
+	_, err = waitForOperation(ctx, meta, op.Id)
+	return err
+}
+
+// rotateDatabaseCredentials replaces the password of each of the database's
+// credentials and waits for the rotations to complete
+func rotateDatabaseCredentials(ctx context.Context, meta interface{}, databaseID int32) error {
+	client := meta.(*providerMetadata).Client
+
+	database, _, err := client.DatabasesAPI.GetDatabase(ctx, databaseID).Execute()
+	if err != nil {
+		return err
+	}
+
+	for _, cred := range database.Embedded.GetDatabaseCredentials() {
+		log.Printf("Rotating %s credential with ID: %d of database with ID: %d\n", cred.Type, cred.Id, databaseID)
+
+		op, _, err := client.OperationsAPI.
+			CreateOperationForDatabaseCredential(ctx, cred.Id).
+			CreateOperationRequest(*aptibleapi.NewCreateOperationRequest("rotate")).
+			Execute()
+		if err != nil {
+			return fmt.Errorf("failed to rotate the %s credential: %w", cred.Type, err)
+		}
+		if _, err := waitForOperation(ctx, meta, op.Id); err != nil {
+			return fmt.Errorf("failed to rotate the %s credential: %w", cred.Type, err)
+		}
+	}
+	return nil
+}
+
+// upgradeDatabase moves the database to the image for the given version using
+// the given upgrade strategy, and waits for the upgrade to complete
+func upgradeDatabase(ctx context.Context, meta interface{}, databaseID int32, databaseType string, version string, strategy string) error {
+	client := meta.(*providerMetadata).Client
+
+	images, err := listDatabaseImages(ctx, meta)
+	if err != nil {
+		return err
+	}
+	image, err := findDatabaseImage(images, databaseType, version)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Upgrading database with ID: %d to %s %s using %s\n", databaseID, databaseType, version, strategy)
+
+	payload := aptibleapi.NewCreateOperationRequest("upgrade")
+	payload.SetSettings(map[string]string{
+		"database_image_id": strconv.Itoa(int(image.Id)),
+		"upgrade_strategy":  strategy,
+	})
+	op, _, err := client.OperationsAPI.
+		CreateOperationForDatabase(ctx, databaseID).
+		CreateOperationRequest(*payload).
+		Execute()
+	if err != nil {
+		return err
+	}
+	_, err = waitForOperation(ctx, meta, op.Id)
+	return err
+}
+
+// findDatabaseImage returns the image of the given type and version
+func findDatabaseImage(images []aptibleapi.DatabaseImage, databaseType string, version string) (*aptibleapi.DatabaseImage, error) {
+	versions := []string{}
+	for i := range images {
+		if images[i].Type != databaseType {
+			continue
+		}
+		if images[i].Version == version {
+			return &images[i], nil
+		}
+		if images[i].Discoverable {
+			versions = append(versions, images[i].Version)
+		}
+	}
+	return nil, fmt.Errorf("version %q is not available for %s databases, available versions are: %s", version, databaseType, strings.Join(versions, ", "))
+}
+
+func resourceDatabaseDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	databaseID := int32(d.Get("database_id").(int))
+
+	if diags := deprovisionDatabase(ctx, meta, databaseID); diags.HasError() {
+		return diags
 	}
 
 	d.SetId("")
 	return nil
 }
 
+// deprovisionDatabase deletes a database or replica and waits for the
+// deprovision operation to complete
+func deprovisionDatabase(ctx context.Context, meta interface{}, databaseID int32) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+
+	op, _, err := m.Client.OperationsAPI.
+		CreateOperationForDatabase(m.APIContext(ctx), databaseID).
+		CreateOperationRequest(*aptibleapi.NewCreateOperationRequest("deprovision")).
+		Execute()
+	if err != nil {
+		log.Println(err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to create deprovision operation for database %d", databaseID),
+			Detail:   err.Error(),
+		}}
+	}
+
+	if _, err := waitForOperation(ctx, meta, op.Id); err != nil {
+		log.Println(err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to deprovision database %d", databaseID),
+			Detail:   err.Error(),
+		}}
+	}
+
+	return nil
+}
+
+var validUpgradeStrategies = []string{
+	"dump_restore",
+	"pg_upgrade",
+}
+
 var validDBTypes = []string{
 	"couchdb",
 	"elasticsearch",
@@ -0,0 +1,357 @@
+package aptible
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDatabaseBackup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDatabaseBackupCreate,
+		ReadContext:   resourceDatabaseBackupRead,
+		UpdateContext: resourceDatabaseBackupUpdate,
+		DeleteContext: resourceDatabaseBackupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDatabaseBackupImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"database_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"copy_region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"keep_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"backup_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"env_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"copy_backup_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDatabaseBackupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	client := m.Client
+	ctx = m.APIContext(ctx)
+	databaseID := int32(d.Get("database_id").(int))
+	copyRegion := d.Get("copy_region").(string)
+
+	op, _, err := client.OperationsAPI.
+		CreateOperationForDatabase(ctx, databaseID).
+		CreateOperationRequest(*aptibleapi.NewCreateOperationRequest("backup")).
+		Execute()
+	if err != nil {
+		log.Println(err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to create backup operation for database %d", databaseID),
+			Detail:   err.Error(),
+		}}
+	}
+
+	if _, err := waitForOperation(ctx, meta, op.Id); err != nil {
+		log.Println(err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to back up database %d", databaseID),
+			Detail:   err.Error(),
+		}}
+	}
+
+	// The operation doesn't link to the backup it creates, so it's fetched
+	// again to know when it completed
+	completed, _, err := client.OperationsAPI.GetOperation(ctx, op.Id).Execute()
+	if err != nil {
+		log.Println(err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to fetch backup operation %d", op.Id),
+			Detail:   err.Error(),
+		}}
+	}
+	backup, err := findBackupForOperation(ctx, meta, databaseID, completed)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to find the backup created by operation %d", op.Id),
+			Detail:   err.Error(),
+		}}
+	}
+
+	_ = d.Set("backup_id", int(backup.Id))
+	d.SetId(strconv.Itoa(int(backup.Id)))
+
+	if copyRegion != "" {
+		copyID, err := copyBackup(ctx, meta, backup.Id, copyRegion)
+		if err != nil {
+			// Do not return here so that the read method can hydrate the state
+			diags := diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Failed to copy backup %d to %s", backup.Id, copyRegion),
+				Detail:   err.Error(),
+			}}
+			return append(diags, resourceDatabaseBackupRead(ctx, d, meta)...)
+		}
+		_ = d.Set("copy_backup_id", int(copyID))
+	}
+
+	return resourceDatabaseBackupRead(ctx, d, meta)
+}
+
+// findBackupForOperation returns the manual backup of the database that was
+// created by the backup operation. Operations don't link to the backups they
+// create, so this is the newest manual backup created while the operation ran.
+func findBackupForOperation(ctx context.Context, meta interface{}, databaseID int32, op *aptibleapi.Operation) (*aptibleapi.Backup, error) {
+	client := meta.(*providerMetadata).Client
+	backups, err := listAllPages(func(page int32) ([]aptibleapi.Backup, int32, int32, error) {
+		resp, _, err := client.BackupsAPI.ListBackupsForDatabase(ctx, databaseID).Page(page).Execute()
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		return resp.Embedded.Backups, resp.PerPage, resp.TotalCount, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var newest *aptibleapi.Backup
+	var newestCreated time.Time
+	for _, backup := range selectBackupsForOperation(backups, op.CreatedAt, op.UpdatedAt) {
+		created, _ := time.Parse(time.RFC3339, backup.CreatedAt)
+		if newest == nil || created.After(newestCreated) {
+			backup := backup
+			newest, newestCreated = &backup, created
+		}
+	}
+	if newest == nil {
+		return nil, fmt.Errorf("no manual backup of database %d was created by operation %d between %s and %s", databaseID, op.Id, op.CreatedAt, op.UpdatedAt)
+	}
+	return newest, nil
+}
+
+// selectBackupsForOperation returns the manual backups created between the
+// operation's creation and completion times
+func selectBackupsForOperation(backups []aptibleapi.Backup, operationCreatedAt string, operationUpdatedAt string) []aptibleapi.Backup {
+	opCreated, err := time.Parse(time.RFC3339, operationCreatedAt)
+	if err != nil {
+		return nil
+	}
+	opUpdated, err := time.Parse(time.RFC3339, operationUpdatedAt)
+	if err != nil {
+		return nil
+	}
+
+	var selected []aptibleapi.Backup
+	for _, b := range backups {
+		if !b.GetManual() {
+			continue
+		}
+		created, err := time.Parse(time.RFC3339, b.CreatedAt)
+		if err != nil || created.Before(opCreated) || created.After(opUpdated) {
+			continue
+		}
+		selected = append(selected, b)
+	}
+	return selected
+}
+
+// copyBackup copies the backup to another region and returns the ID of the copy
+func copyBackup(ctx context.Context, meta interface{}, backupID int32, region string) (int32, error) {
+	client := meta.(*providerMetadata).Client
+
+	payload := aptibleapi.NewCreateOperationRequest("copy")
+	payload.SetDestinationRegion(region)
+	op, _, err := client.OperationsAPI.
+		CreateOperationForBackup(ctx, backupID).
+		CreateOperationRequest(*payload).
+		Execute()
+	if err != nil {
+		return 0, err
+	}
+	if _, err := waitForOperation(ctx, meta, op.Id); err != nil {
+		return 0, err
+	}
+
+	copyID, err := findBackupCopy(ctx, meta, backupID, region)
+	if err != nil {
+		return 0, err
+	}
+	if copyID == 0 {
+		return 0, fmt.Errorf("backup %d has no copy in %s", backupID, region)
+	}
+	return copyID, nil
+}
+
+// findBackupCopy returns the ID of the backup's copy in the region, or 0 if
+// the backup has no copy there
+func findBackupCopy(ctx context.Context, meta interface{}, backupID int32, region string) (int32, error) {
+	client := meta.(*providerMetadata).Client
+	copies, err := listAllPages(func(page int32) ([]aptibleapi.Backup, int32, int32, error) {
+		resp, _, err := client.BackupsAPI.ListCopiesForBackup(ctx, backupID).Page(page).Execute()
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		return resp.Embedded.Backups, resp.PerPage, resp.TotalCount, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, c := range copies {
+		if c.AwsRegion == region {
+			return c.Id, nil
+		}
+	}
+	return 0, nil
+}
+
+func resourceDatabaseBackupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	client := m.Client
+	ctx = m.APIContext(ctx)
+	backupID := int32(d.Get("backup_id").(int))
+
+	log.Println("Getting backup with ID: " + strconv.Itoa(int(backupID)))
+
+	backup, resp, err := client.BackupsAPI.GetBackup(ctx, backupID).Execute()
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		d.SetId("")
+		log.Printf("Backup with ID: %d was deleted outside of Terraform. Removing it from Terraform state.", backupID)
+		return nil
+	}
+	if err != nil {
+		log.Println(err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error getting backup with ID: %d", backupID),
+			Detail:   err.Error(),
+		}}
+	}
+
+	links := backup.GetLinks()
+	_ = d.Set("backup_id", int(backup.Id))
+	_ = d.Set("database_id", ExtractIdFromLink(links.Database.GetHref()))
+	_ = d.Set("env_id", ExtractIdFromLink(links.Account.GetHref()))
+	_ = d.Set("region", backup.AwsRegion)
+	_ = d.Set("size", backup.GetSize())
+	_ = d.Set("created_at", backup.CreatedAt)
+
+	copyID := int32(0)
+	if copyRegion := d.Get("copy_region").(string); copyRegion != "" {
+		copyID, err = findBackupCopy(ctx, meta, backupID, copyRegion)
+		if err != nil {
+			log.Println(err)
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error getting copies of backup with ID: %d", backupID),
+				Detail:   err.Error(),
+			}}
+		}
+	}
+	_ = d.Set("copy_backup_id", int(copyID))
+
+	return nil
+}
+
+// Only keep_on_destroy can change without replacing the backup, and it's only
+// used by Delete
+func resourceDatabaseBackupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceDatabaseBackupRead(ctx, d, meta)
+}
+
+func resourceDatabaseBackupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	backupID := int32(d.Get("backup_id").(int))
+
+	if d.Get("keep_on_destroy").(bool) {
+		log.Printf("Keeping backup with ID: %d, only removing it from Terraform state.", backupID)
+		d.SetId("")
+		return nil
+	}
+
+	backupIDs := []int32{backupID}
+	if copyID := int32(d.Get("copy_backup_id").(int)); copyID != 0 {
+		backupIDs = append(backupIDs, copyID)
+	}
+
+	for _, id := range backupIDs {
+		if err := purgeBackup(ctx, meta, id); err != nil {
+			log.Println(err)
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Failed to delete backup %d", id),
+				Detail:   err.Error(),
+			}}
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// purgeBackup deletes the backup, ignoring backups that no longer exist
+func purgeBackup(ctx context.Context, meta interface{}, backupID int32) error {
+	m := meta.(*providerMetadata)
+
+	op, resp, err := m.Client.OperationsAPI.
+		CreateOperationForBackup(m.APIContext(ctx), backupID).
+		CreateOperationRequest(*aptibleapi.NewCreateOperationRequest("purge")).
+		Execute()
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	_, err = waitForOperation(ctx, meta, op.Id)
+	return err
+}
+
+func resourceDatabaseBackupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	backupID, _ := strconv.Atoi(d.Id())
+	_ = d.Set("backup_id", backupID)
+	_ = d.Set("keep_on_destroy", false)
+	diags := resourceDatabaseBackupRead(ctx, d, meta)
+	return []*schema.ResourceData{d}, diagnosticsToError(diags)
+}
@@ -0,0 +1,213 @@
+package aptible
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/aptible/go-deploy/aptible"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testBackup(id int32, createdAt string, manual bool) aptibleapi.Backup {
+	return aptibleapi.Backup{Id: id, CreatedAt: createdAt, Manual: *aptibleapi.NewNullableBool(&manual)}
+}
+
+func TestSelectBackupsForOperation(t *testing.T) {
+	tests := []struct {
+		name    string
+		backups []aptibleapi.Backup
+		want    []int32
+	}{
+		{
+			name: "picks manual backups created while the operation ran",
+			backups: []aptibleapi.Backup{
+				testBackup(4, "2024-05-01T12:30:00Z", true),
+				testBackup(3, "2024-05-01T12:10:00Z", true),
+				testBackup(2, "2024-05-01T12:01:00.123Z", true),
+				testBackup(1, "2024-05-01T11:00:00Z", true),
+			},
+			want: []int32{3, 2},
+		},
+		{
+			name: "ignores automatic backups",
+			backups: []aptibleapi.Backup{
+				testBackup(2, "2024-05-01T12:01:00Z", false),
+				testBackup(1, "2024-05-01T12:05:00Z", true),
+			},
+			want: []int32{1},
+		},
+		{
+			name: "returns nothing when every backup is outside the operation",
+			backups: []aptibleapi.Backup{
+				testBackup(2, "2024-05-01T12:20:00Z", true),
+				testBackup(1, "2024-05-01T11:00:00Z", true),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectBackupsForOperation(tt.backups, "2024-05-01T12:00:00Z", "2024-05-01T12:15:00Z")
+			ids := []int32{}
+			for _, b := range got {
+				ids = append(ids, b.Id)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.want) {
+				t.Fatalf("selectBackupsForOperation() = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindBackupForOperation(t *testing.T) {
+	op := &aptibleapi.Operation{Id: 42, Type: "backup", CreatedAt: "2024-05-01T12:00:00Z", UpdatedAt: "2024-05-01T12:15:00Z"}
+
+	tests := []struct {
+		name    string
+		backups []aptibleapi.Backup
+		want    int32
+		wantErr string
+	}{
+		{
+			name: "picks the manual backup created while the operation ran",
+			backups: []aptibleapi.Backup{
+				testBackup(3, "2024-05-01T12:20:00Z", true),
+				testBackup(2, "2024-05-01T12:05:00Z", true),
+				testBackup(1, "2024-05-01T11:00:00Z", true),
+			},
+			want: 2,
+		},
+		{
+			name: "picks the newest backup when several were created",
+			backups: []aptibleapi.Backup{
+				testBackup(3, "2024-05-01T12:10:00Z", false),
+				testBackup(2, "2024-05-01T12:05:00Z", true),
+				testBackup(1, "2024-05-01T12:01:00Z", true),
+			},
+			want: 2,
+		},
+		{
+			name: "fails when no manual backup was created",
+			backups: []aptibleapi.Backup{
+				testBackup(2, "2024-05-01T12:05:00Z", false),
+				testBackup(1, "2024-05-01T11:00:00Z", true),
+			},
+			wantErr: "no manual backup of database 7 was created by operation 42",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Only the database's backups are listed, newest first, each linking
+			// back to the database like the API's responses do
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/databases/7/backups" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				resp := aptibleapi.ListBackups200Response{PerPage: 50, TotalCount: int32(len(tt.backups))}
+				for _, b := range tt.backups {
+					b.AwsRegion = "us-east-1"
+					b.UpdatedAt = b.CreatedAt
+					b.Links = &aptibleapi.BackupLinks{
+						Database: &aptibleapi.ListAccountsForStack200ResponseLinksStack{Href: aptibleapi.PtrString("https://api.aptible.com/databases/7")},
+					}
+					resp.Embedded.Backups = append(resp.Embedded.Backups, b)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(resp)
+			}))
+			defer server.Close()
+
+			meta := testProviderMetadata(server)
+			got, err := findBackupForOperation(meta.APIContext(context.Background()), meta, 7, op)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("findBackupForOperation() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Id != tt.want {
+				t.Fatalf("findBackupForOperation() = %d, want %d", got.Id, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccResourceDatabaseBackup_basic(t *testing.T) {
+	dbHandle := acctest.RandString(10)
+
+	WithTestAccEnvironment(t, func(env aptible.Environment) {
+		resource.ParallelTest(t, resource.TestCase{
+			PreCheck:          func() { testAccPreCheck(t) },
+			ProviderFactories: testAccProviderFactories,
+			CheckDestroy:      testAccCheckDatabaseBackupDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccAptibleDatabaseBackup(env.ID, dbHandle),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrPair("aptible_database.test", "database_id", "aptible_database_backup.test", "database_id"),
+						resource.TestCheckResourceAttr("aptible_database_backup.test", "env_id", strconv.Itoa(int(env.ID))),
+						resource.TestCheckResourceAttrSet("aptible_database_backup.test", "backup_id"),
+						resource.TestCheckResourceAttrSet("aptible_database_backup.test", "region"),
+						resource.TestCheckResourceAttrSet("aptible_database_backup.test", "created_at"),
+						resource.TestCheckResourceAttr("aptible_database_backup.test", "size", "10"),
+					),
+				},
+				{
+					ResourceName:      "aptible_database_backup.test",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+	})
+}
+
+func testAccCheckDatabaseBackupDestroy(s *terraform.State) error {
+	m := testAccProvider.Meta().(*providerMetadata)
+	ctx := m.APIContext(context.Background())
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aptible_database_backup" {
+			continue
+		}
+
+		backupID, err := strconv.Atoi(rs.Primary.Attributes["backup_id"])
+		if err != nil {
+			return err
+		}
+
+		_, resp, err := m.Client.BackupsAPI.GetBackup(ctx, int32(backupID)).Execute()
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("backup %v not removed", backupID)
+	}
+	return nil
+}
+
+func testAccAptibleDatabaseBackup(envId int64, dbHandle string) string {
+	return fmt.Sprintf(`
+%s
+
+	resource "aptible_database_backup" "test" {
+		database_id = aptible_database.test.database_id
+	}
+`, testAccAptibleDatabaseBasic(envId, dbHandle))
+}
@@ -40,6 +40,15 @@ func TestAccResourceDatabase_basic(t *testing.T) {
 						resource.TestCheckResourceAttrSet("aptible_database.test", "database_image_id"),
 						resource.TestMatchResourceAttr("aptible_database.test", "default_connection_url", regexp.MustCompile(`postgresql://.*@db-.*`)),
 						resource.TestCheckResourceAttrPair("aptible_database.test", "connection_urls.0", "aptible_database.test", "default_connection_url"),
+						resource.TestCheckResourceAttr("aptible_database.test", "credentials.#", "1"),
+						resource.TestCheckResourceAttr("aptible_database.test", "credentials.0.type", "postgresql"),
+						resource.TestCheckResourceAttr("aptible_database.test", "credentials.0.default", "true"),
+						resource.TestCheckResourceAttr("aptible_database.test", "credentials.0.username", "aptible"),
+						resource.TestCheckResourceAttrPair("aptible_database.test", "credentials.0.connection_url", "aptible_database.test", "default_connection_url"),
+						resource.TestCheckResourceAttrSet("aptible_database.test", "credentials.0.credential_id"),
+						resource.TestCheckResourceAttrSet("aptible_database.test", "credentials.0.host"),
+						resource.TestCheckResourceAttrSet("aptible_database.test", "credentials.0.port"),
+						resource.TestCheckResourceAttrSet("aptible_database.test", "credentials.0.password"),
 					),
 				},
 				{
@@ -125,6 +134,7 @@ func TestAccResourceDatabase_redis(t *testing.T) {
 						resource.TestCheckResourceAttrSet("aptible_database.test", "database_image_id"),
 						resource.TestMatchResourceAttr("aptible_database.test", "default_connection_url", regexp.MustCompile(`redis://.*@db-.*`)),
 						resource.TestCheckResourceAttr("aptible_database.test", "connection_urls.#", "2"),
+						resource.TestCheckResourceAttr("aptible_database.test", "credentials.#", "2"),
 						checkConnectionUrlsInclude("aptible_database.test", []string{
 							`redis://.*@db-.*`,
 							`rediss://.*@db-.*`,
@@ -237,6 +247,180 @@ func TestAccResourceDatabase_update(t *testing.T) {
 	})
 }
 
+func TestAccResourceDatabase_restoreFromBackup(t *testing.T) {
+	dbHandle := acctest.RandString(10)
+
+	WithTestAccEnvironment(t, func(env aptible.Environment) {
+		resource.ParallelTest(t, resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckDatabaseDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccAptibleDatabaseRestoreFromBackup(env.ID, dbHandle),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("aptible_database.restored", "handle", dbHandle+"-restored"),
+						resource.TestCheckResourceAttr("aptible_database.restored", "env_id", strconv.Itoa(int(env.ID))),
+						resource.TestCheckResourceAttr("aptible_database.restored", "database_type", "postgresql"),
+						resource.TestCheckResourceAttr("aptible_database.restored", "container_size", "512"),
+						resource.TestCheckResourceAttr("aptible_database.restored", "disk_size", "20"),
+						resource.TestCheckResourceAttrPair("aptible_database.test", "version", "aptible_database.restored", "version"),
+						resource.TestCheckResourceAttrPair("aptible_database_backup.test", "backup_id", "aptible_database.restored", "restore_from_backup_id"),
+					),
+				},
+				{
+					Config:             testAccAptibleDatabaseRestoreFromBackup(env.ID, dbHandle),
+					PlanOnly:           true,
+					ExpectNonEmptyPlan: false,
+				},
+				{
+					ResourceName:            "aptible_database.restored",
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"restore_from_backup_id"},
+				},
+			},
+		})
+	})
+}
+
+func TestAccResourceDatabase_upgrade(t *testing.T) {
+	dbHandle := acctest.RandString(10)
+
+	WithTestAccEnvironment(t, func(env aptible.Environment) {
+		resource.ParallelTest(t, resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckDatabaseDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccAptibleDatabaseUpgrade(env.ID, dbHandle, "15", ""),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("aptible_database.test", "version", "15"),
+					),
+				},
+				{
+					Config:      testAccAptibleDatabaseUpgrade(env.ID, dbHandle, "16", ""),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(`version can only be changed when upgrade_strategy is set`),
+				},
+				{
+					Config: testAccAptibleDatabaseUpgrade(env.ID, dbHandle, "16", "pg_upgrade"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("aptible_database.test", "version", "16"),
+						resource.TestCheckResourceAttr("aptible_database.test", "upgrade_strategy", "pg_upgrade"),
+					),
+				},
+			},
+		})
+	})
+}
+
+func TestAccResourceDatabase_rotateCredentials(t *testing.T) {
+	dbHandle := acctest.RandString(10)
+	var password string
+
+	WithTestAccEnvironment(t, func(env aptible.Environment) {
+		resource.ParallelTest(t, resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckDatabaseDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccAptibleDatabaseRotateCredentials(env.ID, dbHandle, "initial"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("aptible_database.test", "credentials_rotation", "initial"),
+						func(s *terraform.State) error {
+							password = s.RootModule().Resources["aptible_database.test"].Primary.Attributes["credentials.0.password"]
+							return nil
+						},
+					),
+				},
+				{
+					Config: testAccAptibleDatabaseRotateCredentials(env.ID, dbHandle, "rotated"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("aptible_database.test", "credentials_rotation", "rotated"),
+						resource.TestCheckResourceAttrPair("aptible_database.test", "credentials.0.connection_url", "aptible_database.test", "default_connection_url"),
+						func(s *terraform.State) error {
+							rotated := s.RootModule().Resources["aptible_database.test"].Primary.Attributes["credentials.0.password"]
+							if rotated == "" || rotated == password {
+								return fmt.Errorf("expected the database password to be rotated")
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+}
+
+func TestAccResourceDatabase_parameters(t *testing.T) {
+	dbHandle := acctest.RandString(10)
+
+	WithTestAccEnvironment(t, func(env aptible.Environment) {
+		resource.ParallelTest(t, resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckDatabaseDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccAptibleDatabaseParameters(env.ID, dbHandle, `{ max_connections = "150" }`),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("aptible_database.test", "parameters.%", "1"),
+						resource.TestCheckResourceAttr("aptible_database.test", "parameters.max_connections", "150"),
+					),
+				},
+				{
+					Config: testAccAptibleDatabaseParameters(env.ID, dbHandle, `{ work_mem = "8MB" }`),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("aptible_database.test", "parameters.%", "1"),
+						resource.TestCheckResourceAttr("aptible_database.test", "parameters.work_mem", "8MB"),
+					),
+				},
+				{
+					Config:      testAccAptibleDatabaseParameters(env.ID, dbHandle, `{ "work mem" = "8MB" }`),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(`invalid parameter name "work mem"`),
+				},
+			},
+		})
+	})
+}
+
+func TestAccResourceDatabase_restartTriggers(t *testing.T) {
+	dbHandle := acctest.RandString(10)
+
+	WithTestAccEnvironment(t, func(env aptible.Environment) {
+		resource.ParallelTest(t, resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckDatabaseDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccAptibleDatabaseRestartTriggers(env.ID, dbHandle, "1"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("aptible_database.test", "restart_triggers.certificate", "1"),
+						resource.TestCheckResourceAttr("aptible_database.test", "restart_on_handle_change", "true"),
+					),
+				},
+				{
+					Config: testAccAptibleDatabaseRestartTriggers(env.ID, dbHandle, "2"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("aptible_database.test", "restart_triggers.certificate", "2"),
+					),
+				},
+				{
+					Config: testAccAptibleDatabaseRestartTriggers(env.ID, dbHandle+"-renamed", "2"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("aptible_database.test", "handle", dbHandle+"-renamed"),
+					),
+				},
+			},
+		})
+	})
+}
+
 func TestAccResourceDatabase_expectError(t *testing.T) {
 	dbHandle := acctest.RandString(10)
 
@@ -490,3 +674,62 @@ func checkConnectionUrlsInclude(resourceName string, expectedPatterns []string)
 		return nil
 	}
 }
+
+func testAccAptibleDatabaseRestoreFromBackup(envId int64, dbHandle string) string {
+	return fmt.Sprintf(`
+%s
+
+	resource "aptible_database" "restored" {
+		env_id = %d
+		handle = "%v-restored"
+		container_size = 512
+		disk_size = 20
+		restore_from_backup_id = aptible_database_backup.test.backup_id
+	}
+`, testAccAptibleDatabaseBackup(envId, dbHandle), envId, dbHandle)
+}
+
+func testAccAptibleDatabaseRotateCredentials(envId int64, dbHandle string, rotation string) string {
+	return fmt.Sprintf(`
+	resource "aptible_database" "test" {
+		env_id = %d
+		handle = "%v"
+		credentials_rotation = "%s"
+	}
+`, envId, dbHandle, rotation)
+}
+
+func testAccAptibleDatabaseParameters(envId int64, dbHandle string, parameters string) string {
+	return fmt.Sprintf(`
+	resource "aptible_database" "test" {
+		env_id = %d
+		handle = "%v"
+		parameters = %s
+	}
+`, envId, dbHandle, parameters)
+}
+
+func testAccAptibleDatabaseRestartTriggers(envId int64, dbHandle string, trigger string) string {
+	return fmt.Sprintf(`
+	resource "aptible_database" "test" {
+		env_id = %d
+		handle = "%v"
+		restart_on_handle_change = true
+		restart_triggers = {
+			certificate = "%s"
+		}
+	}
+`, envId, dbHandle, trigger)
+}
+
+func testAccAptibleDatabaseUpgrade(envId int64, dbHandle string, version string, strategy string) string {
+	return fmt.Sprintf(`
+	resource "aptible_database" "test" {
+		env_id = %d
+		handle = "%v"
+		database_type = "postgresql"
+		version = "%s"
+		upgrade_strategy = %q == "" ? null : %q
+	}
+`, envId, dbHandle, version, strategy, strategy)
+}
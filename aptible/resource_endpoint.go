@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/netip"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aptible/aptible-api-go/aptibleapi"
 	"github.com/aptible/go-deploy/aptible"
@@ -27,6 +31,19 @@ func resourceEndpoint() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceEndpointImport,
 		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceEndpointV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceEndpointStateUpgradeV0,
+				Version: 0,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"env_id": {
@@ -73,6 +90,11 @@ func resourceEndpoint() *schema.Resource {
 				Default:  "",
 				ForceNew: true,
 			},
+			"certificate_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
 			"internal": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -93,10 +115,23 @@ func resourceEndpoint() *schema.Resource {
 				ValidateFunc: validation.IntBetween(1, 65535),
 			},
 			"ip_filtering": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Optional: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+				MaxItems: maxIPFilteringEntries,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cidr": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateIPFilteringSource,
+						},
+						// Descriptions aren't sent to Aptible, they're only kept in
+						// the state
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
 				},
 			},
 			"platform": {
@@ -129,6 +164,7 @@ func resourceEndpoint() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"acme_challenges": acmeChallengesSchema(),
 			"shared": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -255,6 +291,42 @@ func resourceEndpointValidate(_ context.Context, diff *schema.ResourceDiff, _ in
 		err = multierror.Append(err, fmt.Errorf("do not specify a load balancing algorithm with %s endpoint", platform))
 	}
 
+	// Sources are compared as CIDRs, so "1.2.3.4" and "1.2.3.4/32" are the same
+	seenSources := map[netip.Prefix]string{}
+	for _, v := range d.Get("ip_filtering").(*schema.Set).List() {
+		source := v.(map[string]interface{})["cidr"].(string)
+		prefix, parseErr := parseIPFilteringSource(source)
+		if parseErr != nil {
+			// Invalid and unknown sources are reported by the attribute's validation
+			continue
+		}
+		if other, ok := seenSources[prefix]; ok {
+			err = multierror.Append(err, fmt.Errorf("ip_filtering contains %s more than once (%q and %q)", prefix, other, source))
+			continue
+		}
+		seenSources[prefix] = source
+	}
+
+	// Managed and default domain endpoints get their certificate from Aptible.
+	// The raw config is used because the certificate_id of those endpoints is
+	// computed.
+	if !diff.GetRawConfig().GetAttr("certificate_id").IsNull() {
+		if d.Get("managed").(bool) || d.Get("default_domain").(bool) {
+			err = multierror.Append(err, fmt.Errorf("do not specify certificate_id with managed or default_domain endpoints"))
+		}
+		if endpointType == "tcp" {
+			err = multierror.Append(err, fmt.Errorf("do not specify certificate_id with a tcp endpoint"))
+		}
+	} else if diff.Id() != "" && !d.Get("managed").(bool) && !d.Get("default_domain").(bool) {
+		// Because certificate_id is computed, removing it from the config
+		// would otherwise keep the certificate in state and never detach it
+		if old, _ := diff.GetChange("certificate_id"); old.(int) != 0 {
+			if setErr := diff.SetNew("certificate_id", 0); setErr != nil {
+				err = multierror.Append(err, setErr)
+			}
+		}
+	}
+
 	category, categoryErr := endpointCategory(endpointType, platform)
 	if categoryErr != nil {
 		return multierror.Append(err, categoryErr)
@@ -373,14 +445,7 @@ func resourceEndpointCreate(ctx context.Context, d *schema.ResourceData, meta in
 	processType := d.Get("process_type").(string)
 	resourceID := int64(d.Get("resource_id").(int))
 	resourceType := d.Get("resource_type").(string)
-	ipWhitelist, err := makeStringSlice(d.Get("ip_filtering").([]interface{}))
-	if err != nil {
-		return append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Validation Error",
-			Detail:   fmt.Sprintf("Failed to parse ip_filtering: %s", err.Error()),
-		})
-	}
+	ipWhitelist := expandIPFiltering(d.Get("ip_filtering").(*schema.Set))
 	containerPorts, err := makeInt32Slice(d.Get("container_ports").([]interface{}))
 	if err != nil {
 		return append(diags, diag.Diagnostic{
@@ -492,6 +557,9 @@ func resourceEndpointCreate(ctx context.Context, d *schema.ResourceData, meta in
 	if domain != "" {
 		attrs.SetUserDomain(domain)
 	}
+	if certificateID, ok := d.GetOk("certificate_id"); ok {
+		attrs.SetCertificate(int32(certificateID.(int)))
+	}
 
 	endpoint, _, err := client.VhostsAPI.
 		CreateVhost(ctx, int32(service.ID)).
@@ -526,7 +594,7 @@ func resourceEndpointCreate(ctx context.Context, d *schema.ResourceData, meta in
 	_ = d.Set("endpoint_id", endpoint.Id)
 	d.SetId(strconv.Itoa(int(endpoint.Id)))
 
-	_, err = legacy.WaitForOperation(int64(operation.Id))
+	_, err = waitForOperation(ctx, meta, operation.Id)
 	if err != nil {
 		// Do not return here so that the read method can hydrate the state
 		diags = append(diags, diag.Diagnostic{
@@ -554,7 +622,7 @@ func resourceEndpointRead(ctx context.Context, d *schema.ResourceData, meta inte
 	endpointID := int32(d.Get("endpoint_id").(int))
 
 	endpoint, resp, err := client.VhostsAPI.GetVhost(ctx, endpointID).Execute()
-	if resp.StatusCode == http.StatusNotFound {
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
 		d.SetId("")
 		log.Printf("Endpoint with ID: %d was deleted outside of Terraform. Removing it from Terraform state.", endpointID)
 		return nil
@@ -606,15 +674,15 @@ func resourceEndpointRead(ctx context.Context, d *schema.ResourceData, meta inte
 	_ = d.Set("endpoint_id", endpoint.GetId())
 	_ = d.Set("endpoint_type", endpointType)
 	_ = d.Set("resource_type", resourceType)
-	_ = d.Set("ip_filtering", endpoint.GetIpWhitelist())
+	_ = d.Set("ip_filtering", flattenIPFiltering(endpoint.GetIpWhitelist(), d.Get("ip_filtering").(*schema.Set)))
 	_ = d.Set("env_id", ExtractIdFromLink(service.Links.Account.GetHref()))
 	_ = d.Set("resource_id", resourceID)
 	_ = d.Set("default_domain", endpoint.GetDefault())
 	_ = d.Set("managed", endpoint.GetAcme())
 	_ = d.Set("domain", endpoint.GetUserDomain())
+	_ = d.Set("certificate_id", ExtractIdFromLink(endpoint.Links.Certificate.GetHref()))
 	_ = d.Set("virtual_domain", endpoint.GetVirtualDomain())
 	_ = d.Set("internal", endpoint.GetInternal())
-	_ = d.Set("ip_filtering", endpoint.GetIpWhitelist())
 	_ = d.Set("platform", endpoint.GetPlatform())
 	_ = d.Set("external_hostname", endpoint.GetExternalHost())
 	_ = d.Set("shared", endpoint.GetShared())
@@ -646,7 +714,7 @@ func resourceEndpointRead(ctx context.Context, d *schema.ResourceData, meta inte
 		}
 
 		if toName == nil {
-			return
+			continue
 		}
 
 		_ = d.Set("dns_validation_record", c.From.GetName())
@@ -654,6 +722,8 @@ func resourceEndpointRead(ctx context.Context, d *schema.ResourceData, meta inte
 		break
 	}
 
+	_ = d.Set("acme_challenges", flattenAcmeChallenges(endpoint.GetAcmeConfiguration().Challenges))
+
 	currentSettingLink, hasCurrentSetting := endpoint.Links.GetCurrentSettingOk()
 	if hasCurrentSetting {
 		currentSettingID := ExtractIdFromLink(currentSettingLink.GetHref())
@@ -673,11 +743,103 @@ func resourceEndpointRead(ctx context.Context, d *schema.ResourceData, meta inte
 	return nil
 }
 
+// expandIPFiltering returns the sources of an endpoint's IP filtering in a
+// stable order
+func expandIPFiltering(set *schema.Set) []string {
+	sources := make([]string, 0, set.Len())
+	for _, v := range set.List() {
+		sources = append(sources, v.(map[string]interface{})["cidr"].(string))
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// flattenIPFiltering returns the endpoint's IP filtering sources. Sources
+// that match one in the current state keep its spelling and description, which
+// Aptible doesn't store.
+func flattenIPFiltering(sources []string, current *schema.Set) []interface{} {
+	known := map[netip.Prefix]map[string]interface{}{}
+	if current != nil {
+		for _, v := range current.List() {
+			entry := v.(map[string]interface{})
+			if prefix, err := parseIPFilteringSource(entry["cidr"].(string)); err == nil {
+				known[prefix] = entry
+			}
+		}
+	}
+
+	flattened := make([]interface{}, 0, len(sources))
+	for _, source := range sources {
+		entry := map[string]interface{}{
+			"cidr":        source,
+			"description": "",
+		}
+		if prefix, err := parseIPFilteringSource(source); err == nil {
+			if k, ok := known[prefix]; ok {
+				entry["cidr"] = k["cidr"]
+				entry["description"] = k["description"]
+			}
+		}
+		flattened = append(flattened, entry)
+	}
+	return flattened
+}
+
+func acmeChallengesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"method": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"from": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"to": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"legacy": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// flattenAcmeChallenges returns an entry for each record of each of the
+// endpoint's ACME challenges, so that wildcard and multi-name endpoints can
+// create every record they need
+func flattenAcmeChallenges(challenges []aptibleapi.VhostAcmeConfigurationChallengesInner) []map[string]interface{} {
+	flattened := []map[string]interface{}{}
+	for _, c := range challenges {
+		if c.From == nil {
+			continue
+		}
+		for _, to := range c.To {
+			if to.Name == nil {
+				continue
+			}
+			flattened = append(flattened, map[string]interface{}{
+				"method": c.GetMethod(),
+				"from":   c.From.GetName(),
+				"to":     to.GetName(),
+				"legacy": to.GetLegacy(),
+			})
+		}
+	}
+	return flattened
+}
+
 // changes state of actual resource based on changes made in a Terraform config file
 func resourceEndpointUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	m := meta.(*providerMetadata)
 	client := m.Client
-	legacy := m.LegacyClient
 	ctx = m.APIContext(ctx)
 	diags := diag.Diagnostics{}
 
@@ -687,17 +849,15 @@ func resourceEndpointUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	settingsMap := map[string]string{}
 	attrs := aptibleapi.NewUpdateVhostRequest()
 
+	// Changing only the descriptions doesn't need the endpoint to be
+	// reprovisioned
 	if d.HasChange("ip_filtering") {
-		needsDeploy = true
-		ipWhitelist, err := makeStringSlice(d.Get("ip_filtering").([]interface{}))
-		if err != nil {
-			return append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Validation Error",
-				Detail:   fmt.Sprintf("Failed to parse ip_filtering: %s", err.Error()),
-			})
+		o, n := d.GetChange("ip_filtering")
+		ipWhitelist := expandIPFiltering(n.(*schema.Set))
+		if !reflect.DeepEqual(expandIPFiltering(o.(*schema.Set)), ipWhitelist) {
+			needsDeploy = true
+			attrs.SetIpWhitelist(ipWhitelist)
 		}
-		attrs.SetIpWhitelist(ipWhitelist)
 	}
 
 	if d.HasChange("container_port") {
@@ -718,6 +878,18 @@ func resourceEndpointUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		attrs.SetContainerPorts(containerPorts)
 	}
 
+	// Rotating the certificate only needs the endpoint to be reprovisioned
+	if d.HasChange("certificate_id") {
+		needsDeploy = true
+		if certificateID, ok := d.GetOk("certificate_id"); ok {
+			attrs.SetCertificate(int32(certificateID.(int)))
+		} else {
+			// The client omits an unset certificate, so the link is cleared
+			// explicitly to detach it
+			attrs.AdditionalProperties = map[string]interface{}{"certificate": nil}
+		}
+	}
+
 	if d.HasChange("shared") {
 		needsDeploy = true
 		attrs.SetShared(d.Get("shared").(bool))
@@ -808,7 +980,7 @@ func resourceEndpointUpdate(ctx context.Context, d *schema.ResourceData, meta in
 			})
 		}
 
-		_, err = legacy.WaitForOperation(int64(operation.Id))
+		_, err = waitForOperation(ctx, meta, operation.Id)
 		if err != nil {
 			// Do not return here so that the read method can hydrate the state
 			diags = append(diags, diag.Diagnostic{
@@ -822,19 +994,72 @@ func resourceEndpointUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	return append(diags, resourceEndpointRead(ctx, d, meta)...)
 }
 
-func resourceEndpointDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*providerMetadata).LegacyClient
-	endpointID := int64(d.Get("endpoint_id").(int))
-	err := client.DeleteEndpoint(endpointID)
+func resourceEndpointDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	endpointID := int32(d.Get("endpoint_id").(int))
+
+	operation, _, err := m.Client.OperationsAPI.
+		CreateOperationForVhost(m.APIContext(ctx), endpointID).
+		CreateOperationRequest(*aptibleapi.NewCreateOperationRequest("deprovision")).
+		Execute()
 	if err != nil {
 		log.Println(err)
-		return generateDiagnosticsFromClientError(err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to create deprovision operation for endpoint %d", endpointID),
+			Detail:   err.Error(),
+		}}
+	}
+
+	if _, err := waitForOperation(ctx, meta, operation.Id); err != nil {
+		log.Println(err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to deprovision endpoint %d", endpointID),
+			Detail:   err.Error(),
+		}}
 	}
 
 	d.SetId("")
 	return nil
 }
 
+// resourceEndpointV0 is the endpoint schema before ip_filtering had
+// descriptions. Only ip_filtering changed, so the other attributes are left
+// out.
+func resourceEndpointV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"ip_filtering": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// resourceEndpointStateUpgradeV0 turns each ip_filtering source into a block
+// without a description
+func resourceEndpointStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	sources, _ := rawState["ip_filtering"].([]interface{})
+	upgraded := make([]interface{}, 0, len(sources))
+	for _, v := range sources {
+		source, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected ip_filtering source %v in state", v)
+		}
+		upgraded = append(upgraded, map[string]interface{}{
+			"cidr":        source,
+			"description": "",
+		})
+	}
+	rawState["ip_filtering"] = upgraded
+	return rawState, nil
+}
+
 var validEndpointTypes = []string{
 	"https",
 	"tls",
@@ -0,0 +1,220 @@
+package aptible
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The acme_status of a managed HTTPS endpoint once its certificate is issued
+const acmeStatusReady = "ready"
+
+func resourceEndpointAcmeValidation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEndpointAcmeValidationCreate,
+		ReadContext:   resourceEndpointAcmeValidationRead,
+		DeleteContext: resourceEndpointAcmeValidationDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"endpoint_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"acme_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"certificate_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"common_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"subject_alternative_names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"not_before": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"not_after": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceEndpointAcmeValidationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	client := m.Client
+	ctx = m.APIContext(ctx)
+	endpointID := int32(d.Get("endpoint_id").(int))
+
+	endpoint, _, err := client.VhostsAPI.GetVhost(ctx, endpointID).Execute()
+	if err != nil {
+		log.Println(err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to fetch endpoint with ID %d", endpointID),
+			Detail:   err.Error(),
+		}}
+	}
+	if !endpoint.GetAcme() {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Validation Error",
+			Detail:   fmt.Sprintf("Endpoint %d doesn't use managed HTTPS, set managed = true on the endpoint", endpointID),
+		}}
+	}
+
+	// Endpoints that were already validated don't need to be renewed
+	if endpoint.GetAcmeStatus() != acmeStatusReady {
+		op, _, err := client.OperationsAPI.
+			CreateOperationForVhost(ctx, endpointID).
+			CreateOperationRequest(*aptibleapi.NewCreateOperationRequest("renew")).
+			Execute()
+		if err != nil {
+			log.Println(err)
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Failed to create renew operation for endpoint %d", endpointID),
+				Detail:   err.Error(),
+			}}
+		}
+
+		if _, err := waitForOperation(ctx, meta, op.Id); err != nil {
+			log.Println(err)
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Failed to validate the managed HTTPS certificate for endpoint %d", endpointID),
+				Detail:   fmt.Sprintf("%s\n\nCheck that the endpoint's DNS records exist and have propagated.", err),
+			}}
+		}
+
+		if _, err := waitForAcmeStatus(ctx, meta, endpointID); err != nil {
+			log.Println(err)
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Failed to validate the managed HTTPS certificate for endpoint %d", endpointID),
+				Detail:   err.Error(),
+			}}
+		}
+	}
+
+	d.SetId(strconv.Itoa(int(endpointID)))
+
+	return resourceEndpointAcmeValidationRead(ctx, d, meta)
+}
+
+// waitForAcmeStatus polls the endpoint until its managed HTTPS certificate has
+// been issued, using the same backoff as operations. The renew operation can
+// complete slightly before the endpoint's status is updated.
+func waitForAcmeStatus(ctx context.Context, meta interface{}, endpointID int32) (*aptibleapi.Vhost, error) {
+	m := meta.(*providerMetadata)
+	ctx = m.APIContext(ctx)
+	backoff := defaultOperationBackoff
+	status := "unknown"
+
+	for attempt := 0; ; attempt++ {
+		endpoint, _, err := m.Client.VhostsAPI.GetVhost(ctx, endpointID).Execute()
+		switch {
+		case ctx.Err() != nil:
+			return nil, acmeTimeoutError(ctx, endpointID, status)
+		case err != nil:
+			return nil, fmt.Errorf("there was an error when getting endpoint %d: %w", endpointID, err)
+		}
+
+		status = endpoint.GetAcmeStatus()
+		if status == acmeStatusReady {
+			return endpoint, nil
+		}
+
+		delay := backoff.delay(attempt)
+		log.Printf("[DEBUG] Endpoint %d ACME status is %s, checking again in %s\n", endpointID, status, delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, acmeTimeoutError(ctx, endpointID, status)
+		case <-timer.C:
+		}
+	}
+}
+
+func acmeTimeoutError(ctx context.Context, endpointID int32, status string) error {
+	return fmt.Errorf(
+		"stopped waiting for endpoint %d to be validated, last known ACME status: %s. Increase the resource's create timeout if it needs more time: %w",
+		endpointID, status, ctx.Err(),
+	)
+}
+
+func resourceEndpointAcmeValidationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	client := m.Client
+	ctx = m.APIContext(ctx)
+	endpointID := int32(d.Get("endpoint_id").(int))
+
+	endpoint, resp, err := client.VhostsAPI.GetVhost(ctx, endpointID).Execute()
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		d.SetId("")
+		log.Printf("Endpoint with ID: %d was deleted outside of Terraform. Removing its validation from Terraform state.", endpointID)
+		return nil
+	}
+	if err != nil {
+		log.Println(err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to fetch endpoint with ID %d", endpointID),
+			Detail:   err.Error(),
+		}}
+	}
+
+	_ = d.Set("acme_status", endpoint.GetAcmeStatus())
+
+	certificateID := ExtractIdFromLink(endpoint.Links.Certificate.GetHref())
+	_ = d.Set("certificate_id", int(certificateID))
+	if certificateID == 0 {
+		return nil
+	}
+
+	certificate, _, err := client.CertificatesAPI.GetCertificate(ctx, certificateID).Execute()
+	if err != nil {
+		log.Println(err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to fetch certificate %d for endpoint %d", certificateID, endpointID),
+			Detail:   err.Error(),
+		}}
+	}
+	_ = d.Set("common_name", certificate.CommonName)
+	_ = d.Set("subject_alternative_names", certificate.SubjectAlternativeNames)
+	_ = d.Set("not_before", certificate.NotBefore)
+	_ = d.Set("not_after", certificate.NotAfter)
+
+	return nil
+}
+
+// Validation can't be undone, so destroying the resource only removes it from
+// the state
+func resourceEndpointAcmeValidationDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,66 @@
+package aptible
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Validating a managed endpoint needs DNS records for a domain the tests
+// control, so only the unmanaged case is covered here
+func TestAccResourceEndpointAcmeValidation_unmanagedEndpoint(t *testing.T) {
+	appHandle := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckEndpointDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAptibleEndpointAcmeValidationUnmanaged(appHandle),
+				ExpectError: regexp.MustCompile(`(?i)doesn't use managed HTTPS`),
+			},
+		},
+	})
+}
+
+func testAccAptibleEndpointAcmeValidationUnmanaged(appHandle string) string {
+	output := fmt.Sprintf(`
+	resource "aptible_environment" "test" {
+		handle = "%s"
+		org_id = "%s"
+		stack_id = "%v"
+	}
+
+	resource "aptible_app" "test" {
+		env_id = aptible_environment.test.env_id
+		handle = "%v"
+		docker_image = "quay.io/aptible/nginx-mirror:31"
+		service {
+			process_type = "cmd"
+			container_memory_limit = 512
+			container_count = 1
+		}
+	}
+
+	resource "aptible_endpoint" "test" {
+		env_id = aptible_environment.test.env_id
+		resource_id = aptible_app.test.app_id
+		resource_type = "app"
+		process_type = "cmd"
+		endpoint_type = "https"
+		default_domain = true
+		platform = "alb"
+	}
+
+	resource "aptible_endpoint_acme_validation" "test" {
+		endpoint_id = aptible_endpoint.test.endpoint_id
+	}
+`, appHandle, testOrganizationId, testStackId, appHandle)
+	log.Println("HCL generated: ", output)
+	return output
+}
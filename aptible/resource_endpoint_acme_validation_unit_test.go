@@ -0,0 +1,99 @@
+package aptible
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+)
+
+// testAcmeServer serves endpoint 7, moving through statuses on each request
+// and repeating the last one
+func testAcmeServer(t *testing.T, statuses []string) *httptest.Server {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/vhosts/7" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		status := statuses[len(statuses)-1]
+		if calls < len(statuses) {
+			status = statuses[calls]
+		}
+		calls++
+
+		// The client requires these properties to be present
+		endpoint := aptibleapi.Vhost{
+			Id:                    7,
+			Acme:                  true,
+			ContainerExposedPorts: []int32{},
+			HostMappedPorts:       []int32{},
+			IpWhitelist:           []string{},
+			ContainerPorts:        []int32{},
+		}
+		endpoint.SetAcmeStatus(status)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(endpoint)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWaitForAcmeStatus(t *testing.T) {
+	useFastOperationBackoff(t)
+
+	tests := []struct {
+		name     string
+		statuses []string
+		timeout  time.Duration
+		wantErr  string
+	}{
+		{
+			name:     "already ready",
+			statuses: []string{"ready"},
+		},
+		{
+			name:     "becomes ready",
+			statuses: []string{"pending", "transitioning", "ready"},
+		},
+		{
+			name:     "deadline exceeded",
+			statuses: []string{"transitioning"},
+			timeout:  20 * time.Millisecond,
+			wantErr:  "stopped waiting for endpoint 7 to be validated, last known ACME status: transitioning",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testAcmeServer(t, tt.statuses)
+
+			ctx := context.Background()
+			if tt.timeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			endpoint, err := waitForAcmeStatus(ctx, testProviderMetadata(server), 7)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("waitForAcmeStatus() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("waitForAcmeStatus() unexpected error: %v", err)
+			}
+			if endpoint.GetAcmeStatus() != acmeStatusReady {
+				t.Errorf("waitForAcmeStatus() returned status %q, want %q", endpoint.GetAcmeStatus(), acmeStatusReady)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -40,6 +41,11 @@ func TestAccResourceEndpoint_customDomain(t *testing.T) {
 					resource.TestMatchResourceAttr("aptible_endpoint.test", "external_hostname", regexp.MustCompile(`elb.*`)),
 					resource.TestCheckResourceAttr("aptible_endpoint.test", "dns_validation_record", "_acme-challenge.www.aptible-test-demo.pizza"),
 					resource.TestMatchResourceAttr("aptible_endpoint.test", "dns_validation_value", regexp.MustCompile(`acme\.elb.*`)),
+					resource.TestCheckTypeSetElemNestedAttrs("aptible_endpoint.test", "acme_challenges.*", map[string]string{
+						"method": "dns01",
+						"from":   "_acme-challenge.www.aptible-test-demo.pizza",
+						"legacy": "false",
+					}),
 				),
 			},
 			{
@@ -369,7 +375,15 @@ func TestAccResourceEndpoint_updateIPWhitelist(t *testing.T) {
 			{
 				Config: testAccAptibleEndpointUpdateIPWhitelist(appHandle),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("aptible_endpoint.test", "ip_filtering.0", "1.1.1.1/32"),
+					resource.TestCheckResourceAttr("aptible_endpoint.test", "ip_filtering.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("aptible_endpoint.test", "ip_filtering.*", map[string]string{
+						"cidr":        "1.1.1.1/32",
+						"description": "office",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("aptible_endpoint.test", "ip_filtering.*", map[string]string{
+						"cidr":        "10.0.0.0/16",
+						"description": "",
+					}),
 				),
 			},
 			{
@@ -587,6 +601,26 @@ func TestAccResourceEndpoint_expectError(t *testing.T) {
 				Config:      testAccAptibleEndpointInvalidAppNlbPlatform(),
 				ExpectError: regexp.MustCompile(`(?i)platform 'nlb' is not supported for app endpoints`),
 			},
+			{
+				Config:      testAccAptibleEndpointCertificateWithManaged(),
+				ExpectError: regexp.MustCompile(`(?i)do not specify certificate_id with managed or default_domain endpoints`),
+			},
+			{
+				Config:      testAccAptibleEndpointCertificateOnTcp(),
+				ExpectError: regexp.MustCompile(`(?i)do not specify certificate_id with a tcp endpoint`),
+			},
+			{
+				Config:      testAccAptibleEndpointInvalidIPFiltering(),
+				ExpectError: regexp.MustCompile(`(?i)to be an IPv4 address or CIDR, got "10\.0\.0/24"`),
+			},
+			{
+				Config:      testAccAptibleEndpointDuplicateIPFiltering(),
+				ExpectError: regexp.MustCompile(`(?i)ip_filtering contains 1\.1\.1\.1/32 more than once`),
+			},
+			{
+				Config:      testAccAptibleEndpointTooManyIPFiltering(),
+				ExpectError: regexp.MustCompile(`(?i)too many ip_filtering blocks`),
+			},
 		},
 	})
 }
@@ -908,9 +942,13 @@ func testAccAptibleEndpointUpdateIPWhitelist(appHandle string) string {
 		default_domain = true
 		internal = true
 		platform = "alb"
-		ip_filtering = [
-			"1.1.1.1/32",
-		]
+		ip_filtering {
+			cidr = "1.1.1.1/32"
+			description = "office"
+		}
+		ip_filtering {
+			cidr = "10.0.0.0/16"
+		}
 	}
 `, appHandle, testOrganizationId, testStackId, appHandle)
 	log.Println("HCL generated: ", output)
@@ -1404,3 +1442,93 @@ func testAccAptibleEndpointInvalidAppNlbPlatform() string {
 	log.Println("HCL generated: ", output)
 	return output
 }
+
+func testAccAptibleEndpointCertificateWithManaged() string {
+	output := `
+	resource "aptible_endpoint" "test" {
+		env_id = -1
+		resource_id = 1
+		resource_type = "app"
+		process_type = "cmd"
+		platform = "alb"
+		managed = true
+		domain = "www.aptible-test-demo.pizza"
+		certificate_id = 1
+	}`
+	log.Println("HCL generated: ", output)
+	return output
+}
+
+func testAccAptibleEndpointCertificateOnTcp() string {
+	output := `
+	resource "aptible_endpoint" "test" {
+		env_id = -1
+		endpoint_type = "tcp"
+		resource_id = 1
+		resource_type = "app"
+		process_type = "cmd"
+		certificate_id = 1
+	}`
+	log.Println("HCL generated: ", output)
+	return output
+}
+
+func testAccAptibleEndpointInvalidIPFiltering() string {
+	output := `
+	resource "aptible_endpoint" "test" {
+		env_id = -1
+		resource_id = 1
+		resource_type = "app"
+		process_type = "cmd"
+		default_domain = true
+		platform = "alb"
+		ip_filtering {
+			cidr = "10.0.0/24"
+		}
+	}`
+	log.Println("HCL generated: ", output)
+	return output
+}
+
+func testAccAptibleEndpointDuplicateIPFiltering() string {
+	output := `
+	resource "aptible_endpoint" "test" {
+		env_id = -1
+		resource_id = 1
+		resource_type = "app"
+		process_type = "cmd"
+		default_domain = true
+		platform = "alb"
+		ip_filtering {
+			cidr = "1.1.1.1"
+		}
+		ip_filtering {
+			cidr = "1.1.1.1/32"
+			description = "office"
+		}
+	}`
+	log.Println("HCL generated: ", output)
+	return output
+}
+
+func testAccAptibleEndpointTooManyIPFiltering() string {
+	var sources strings.Builder
+	for i := 0; i <= maxIPFilteringEntries; i++ {
+		fmt.Fprintf(&sources, `
+		ip_filtering {
+			cidr = "10.0.%d.0/24"
+		}`, i)
+	}
+
+	output := fmt.Sprintf(`
+	resource "aptible_endpoint" "test" {
+		env_id = -1
+		resource_id = 1
+		resource_type = "app"
+		process_type = "cmd"
+		default_domain = true
+		platform = "alb"%s
+	}`, sources.String())
+	log.Println("HCL generated: ", output)
+	return output
+}
@@ -1,9 +1,16 @@
 package aptible
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestResourceEndpointPlatformDiffSuppress(t *testing.T) {
@@ -47,3 +54,238 @@ func TestResourceEndpointPlatformDiffSuppress(t *testing.T) {
 		})
 	}
 }
+
+func TestFlattenAcmeChallenges(t *testing.T) {
+	name := func(n string) *string { return &n }
+	legacy := true
+
+	challenges := []aptibleapi.VhostAcmeConfigurationChallengesInner{
+		{
+			Method: name("dns01"),
+			From:   &aptibleapi.UpdateDashboardRequest{Name: name("_acme-challenge.example.com")},
+			To: []aptibleapi.VhostAcmeConfigurationChallengesInnerToInner{
+				{Name: name("acme.elb-1.aptible.in"), Legacy: &legacy},
+				{Name: name("acme.elb-2.aptible.in")},
+			},
+		},
+		{
+			Method: name("dns01"),
+			From:   &aptibleapi.UpdateDashboardRequest{Name: name("_acme-challenge.www.example.com")},
+			// Targets without a name are skipped
+			To: []aptibleapi.VhostAcmeConfigurationChallengesInnerToInner{{}, {Name: name("acme.elb-2.aptible.in")}},
+		},
+		{
+			Method: name("http01"),
+			From:   &aptibleapi.UpdateDashboardRequest{Name: name("www.example.com")},
+			To:     []aptibleapi.VhostAcmeConfigurationChallengesInnerToInner{{Name: name("elb-2.aptible.in")}},
+		},
+		// Challenges without a source are skipped
+		{
+			Method: name("dns01"),
+			To:     []aptibleapi.VhostAcmeConfigurationChallengesInnerToInner{{Name: name("acme.elb-2.aptible.in")}},
+		},
+	}
+
+	want := []map[string]interface{}{
+		{"method": "dns01", "from": "_acme-challenge.example.com", "to": "acme.elb-1.aptible.in", "legacy": true},
+		{"method": "dns01", "from": "_acme-challenge.example.com", "to": "acme.elb-2.aptible.in", "legacy": false},
+		{"method": "dns01", "from": "_acme-challenge.www.example.com", "to": "acme.elb-2.aptible.in", "legacy": false},
+		{"method": "http01", "from": "www.example.com", "to": "elb-2.aptible.in", "legacy": false},
+	}
+
+	if got := flattenAcmeChallenges(challenges); !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenAcmeChallenges() = %v, want %v", got, want)
+	}
+	if got := flattenAcmeChallenges(nil); len(got) != 0 {
+		t.Errorf("flattenAcmeChallenges(nil) = %v, want an empty list", got)
+	}
+}
+
+func TestFlattenIPFiltering(t *testing.T) {
+	ipFilteringSchema := resourceEndpoint().Schema["ip_filtering"]
+	current := schema.NewSet(schema.HashResource(ipFilteringSchema.Elem.(*schema.Resource)), []interface{}{
+		map[string]interface{}{"cidr": "1.1.1.1", "description": "office"},
+		map[string]interface{}{"cidr": "10.0.0.0/16", "description": "vpn"},
+		map[string]interface{}{"cidr": "192.168.0.0/24", "description": "removed"},
+	})
+
+	got := flattenIPFiltering([]string{"1.1.1.1/32", "10.0.0.0/16", "8.8.8.8/32"}, current)
+	want := []interface{}{
+		// The configured spelling is kept when it's the same source
+		map[string]interface{}{"cidr": "1.1.1.1", "description": "office"},
+		map[string]interface{}{"cidr": "10.0.0.0/16", "description": "vpn"},
+		// Sources added outside of Terraform have no description
+		map[string]interface{}{"cidr": "8.8.8.8/32", "description": ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenIPFiltering() = %v, want %v", got, want)
+	}
+
+	if got := flattenIPFiltering([]string{"1.1.1.1/32"}, nil); !reflect.DeepEqual(got, []interface{}{
+		map[string]interface{}{"cidr": "1.1.1.1/32", "description": ""},
+	}) {
+		t.Errorf("flattenIPFiltering() without a current state = %v", got)
+	}
+}
+
+func TestResourceEndpointStateUpgradeV0(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawState map[string]interface{}
+		want     map[string]interface{}
+	}{
+		{
+			name: "converts sources to blocks",
+			rawState: map[string]interface{}{
+				"endpoint_id":  float64(1),
+				"ip_filtering": []interface{}{"1.1.1.1/32", "10.0.0.0/16"},
+			},
+			want: map[string]interface{}{
+				"endpoint_id": float64(1),
+				"ip_filtering": []interface{}{
+					map[string]interface{}{"cidr": "1.1.1.1/32", "description": ""},
+					map[string]interface{}{"cidr": "10.0.0.0/16", "description": ""},
+				},
+			},
+		},
+		{
+			name:     "handles endpoints without ip filtering",
+			rawState: map[string]interface{}{"endpoint_id": float64(1)},
+			want: map[string]interface{}{
+				"endpoint_id":  float64(1),
+				"ip_filtering": []interface{}{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resourceEndpointStateUpgradeV0(context.Background(), tt.rawState, nil)
+			if err != nil {
+				t.Fatalf("resourceEndpointStateUpgradeV0() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resourceEndpointStateUpgradeV0() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceEndpointReadUnreachableAPI(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	meta := testProviderMetadata(server)
+	// With the server gone the request fails without a response
+	server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceEndpoint().Schema, map[string]interface{}{})
+	d.SetId("1")
+	_ = d.Set("endpoint_id", 1)
+
+	diags := resourceEndpointRead(context.Background(), d, meta)
+	if !diags.HasError() {
+		t.Fatal("expected an error reading the endpoint")
+	}
+	if d.Id() != "1" {
+		t.Errorf("endpoint was removed from state, want it kept")
+	}
+}
+
+func TestResourceEndpointCertificateRemovalDiff(t *testing.T) {
+	tests := []struct {
+		name            string
+		state           map[string]string
+		certificateID   interface{}
+		wantCertificate string
+		wantDiff        bool
+	}{
+		{
+			name:            "detaches the certificate when it's removed",
+			wantCertificate: "0",
+			wantDiff:        true,
+		},
+		{
+			name:          "keeps the configured certificate",
+			certificateID: 5,
+		},
+		{
+			name:  "keeps the certificate of a managed endpoint",
+			state: map[string]string{"managed": "true", "domain": "www.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &terraform.InstanceState{ID: "1", Attributes: map[string]string{
+				"id":                            "1",
+				"env_id":                        "1",
+				"resource_id":                   "2",
+				"resource_type":                 "app",
+				"endpoint_type":                 "https",
+				"process_type":                  "web",
+				"certificate_id":                "5",
+				"acme_challenges.#":             "0",
+				"default_domain":                "false",
+				"managed":                       "false",
+				"domain":                        "",
+				"internal":                      "false",
+				"platform":                      "alb",
+				"shared":                        "false",
+				"load_balancing_algorithm_type": "round_robin",
+			}}
+			raw := map[string]interface{}{
+				"env_id":                        1,
+				"resource_id":                   2,
+				"resource_type":                 "app",
+				"process_type":                  "web",
+				"load_balancing_algorithm_type": "round_robin",
+			}
+			for k, v := range tt.state {
+				state.Attributes[k] = v
+				if v == "true" {
+					raw[k] = true
+				} else {
+					raw[k] = v
+				}
+			}
+			rawCertificateID := cty.NullVal(cty.Number)
+			if tt.certificateID != nil {
+				raw["certificate_id"] = tt.certificateID
+				rawCertificateID = cty.NumberIntVal(int64(tt.certificateID.(int)))
+			}
+			state.RawConfig = testResourceRawConfig(resourceEndpoint(), map[string]cty.Value{
+				"certificate_id": rawCertificateID,
+			})
+
+			diff, err := resourceEndpoint().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// A nil diff means nothing is planned
+			if (diff != nil && !diff.Empty()) != tt.wantDiff {
+				t.Fatalf("planned changes = %v, want changes %t", diff, tt.wantDiff)
+			}
+			if tt.wantDiff {
+				attr, ok := diff.GetAttribute("certificate_id")
+				if !ok || attr.New != tt.wantCertificate {
+					t.Fatalf("certificate_id diff = %v, want %q", attr, tt.wantCertificate)
+				}
+			}
+		})
+	}
+}
+
+// testResourceRawConfig builds the raw config the SDK passes to CustomizeDiff,
+// with attrs set and every other attribute null.
+func testResourceRawConfig(r *schema.Resource, attrs map[string]cty.Value) cty.Value {
+	ty := schema.InternalMap(r.Schema).CoreConfigSchema().ImpliedType()
+	values := map[string]cty.Value{}
+	for name, attrType := range ty.AttributeTypes() {
+		if value, ok := attrs[name]; ok {
+			values[name] = value
+		} else {
+			values[name] = cty.NullVal(attrType)
+		}
+	}
+	return cty.ObjectVal(values)
+}
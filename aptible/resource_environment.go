@@ -3,6 +3,7 @@ package aptible
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"strconv"
 
@@ -45,9 +46,9 @@ func resourceEnvironment() *schema.Resource {
 				Required: true,
 			},
 			"backup_retention_policy": {
-				Type:     schema.TypeSet,
+				Type:     schema.TypeList,
 				Optional: true,
-				Computed: true,
+				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"daily": {
@@ -73,6 +74,19 @@ func resourceEnvironment() *schema.Resource {
 							Type:     schema.TypeBool,
 							Required: true,
 						},
+						// Optional and computed so that leaving these out keeps
+						// the environment's current settings
+						"pitr_days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"copy_destination_region": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
 					},
 				},
 			},
@@ -106,7 +120,7 @@ func resourceEnvironmentCreate(ctx context.Context, d *schema.ResourceData, meta
 		}
 		orgID = stack.OrganizationID
 		if orgID == "" {
-			org, err := client.GetOrganization() // scenario #3 outlined above
+			org, err := getOrganization(ctx, meta.(*providerMetadata)) // scenario #3 outlined above
 			if err != nil {
 				log.Println("There was an error trying to retrieve an organization id (org_id). You can "+
 					"either specify it explicitly or review the error message to attempt to fix the issue. "+
@@ -220,11 +234,23 @@ func resourceEnvironmentDelete(ctx context.Context, d *schema.ResourceData, meta
 		if len(drains) != 0 {
 			for _, drain := range drains {
 				if drain.DrainType == "tail" {
-					_, drainErr := legacy.DeleteLogDrain(int64(drain.Id))
+					op, _, drainErr := client.OperationsAPI.
+						CreateOperationForLogDrain(ctx, drain.Id).
+						CreateOperationRequest(*aptibleapi.NewCreateOperationRequest("deprovision")).
+						Execute()
+					if drainErr == nil {
+						_, drainErr = waitForOperation(ctx, meta, op.Id)
+					}
 
 					if drainErr != nil {
 						log.Println("There was an error when completing the request to destroy the log drain.\n[ERROR] -", drainErr)
-						return generateDiagnosticsFromClientError(drainErr)
+						return diag.Diagnostics{
+							diag.Diagnostic{
+								Severity: diag.Error,
+								Summary:  fmt.Sprintf("Error deprovisioning log drain %d", drain.Id),
+								Detail:   drainErr.Error(),
+							},
+						}
 					}
 				}
 			}
@@ -255,12 +281,13 @@ func resourceEnvironmentImport(ctx context.Context, d *schema.ResourceData, meta
 
 // Backup retention policy
 func validateBackupRetentionPolicy(d *schema.ResourceData) diag.Diagnostics {
-	if d.Get("backup_retention_policy").(*schema.Set).Len() > 1 {
+	policy := configuredBackupRetentionPolicy(d)
+	if policy != nil && policy["copy_destination_region"].(string) != "" && !policy["make_copy"].(bool) {
 		return diag.Diagnostics{
 			diag.Diagnostic{
 				Severity: diag.Error,
-				Summary:  "Multiple backup_retention_policy",
-				Detail:   "Environments may only have one backup retention policy",
+				Summary:  "Invalid backup_retention_policy",
+				Detail:   "copy_destination_region can only be set when make_copy is true",
 			},
 		}
 	}
@@ -268,6 +295,30 @@ func validateBackupRetentionPolicy(d *schema.ResourceData) diag.Diagnostics {
 	return nil
 }
 
+// configuredBackupRetentionPolicy returns the backup_retention_policy block,
+// or nil when the environment doesn't manage its policy
+func configuredBackupRetentionPolicy(d *schema.ResourceData) map[string]interface{} {
+	policies := d.Get("backup_retention_policy").([]interface{})
+	if len(policies) == 0 || policies[0] == nil {
+		return nil
+	}
+	return policies[0].(map[string]interface{})
+}
+
+// pitrDaysConfigured reports whether pitr_days is set in the config, since
+// its value alone can't tell 0 from a missing argument
+func pitrDaysConfigured(d *schema.ResourceData) bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		return false
+	}
+	policies := raw.GetAttr("backup_retention_policy")
+	if policies.IsNull() || !policies.IsKnown() || policies.LengthInt() == 0 {
+		return false
+	}
+	return !policies.AsValueSlice()[0].GetAttr("pitr_days").IsNull()
+}
+
 func createBackupRetentionPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	m := meta.(*providerMetadata)
 	client := m.Client
@@ -280,27 +331,64 @@ func createBackupRetentionPolicy(ctx context.Context, d *schema.ResourceData, me
 		return nil
 	}
 
-	policies := d.Get("backup_retention_policy").(*schema.Set).List()
-	if len(policies) < 1 {
-		return nil
-	}
-	policy := policies[0].(map[string]interface{})
-
-	daily := int32(policy["daily"].(int))
-	monthly := int32(policy["monthly"].(int))
-	yearly := int32(policy["yearly"].(int))
-	makeCopy := policy["make_copy"].(bool)
-	keepFinal := policy["keep_final"].(bool)
-
-	_, err := client.BackupRetentionPoliciesAPI.
-		CreateBackupRetentionPolicy(ctx, envId).
-		CreateBackupRetentionPolicyRequest(aptibleapi.CreateBackupRetentionPolicyRequest{
+	var request aptibleapi.CreateBackupRetentionPolicyRequest
+	if policy := configuredBackupRetentionPolicy(d); policy != nil {
+		daily := int32(policy["daily"].(int))
+		monthly := int32(policy["monthly"].(int))
+		yearly := int32(policy["yearly"].(int))
+		makeCopy := policy["make_copy"].(bool)
+		keepFinal := policy["keep_final"].(bool)
+		request = aptibleapi.CreateBackupRetentionPolicyRequest{
 			Daily:     &daily,
 			Monthly:   &monthly,
 			Yearly:    &yearly,
 			MakeCopy:  &makeCopy,
 			KeepFinal: &keepFinal,
-		}).
+		}
+
+		pitrDays := policy["pitr_days"].(int)
+		if !pitrDaysConfigured(d) {
+			current, err := listBackupRetentionPolicies(ctx, meta, envId)
+			if err != nil {
+				return diag.Diagnostics{
+					diag.Diagnostic{
+						Severity: diag.Error,
+						Summary:  "Error fetching backup retention policy",
+						Detail:   err.Error(),
+					},
+				}
+			}
+			pitrDays = 0
+			if len(current) > 0 {
+				pitrDays = int(current[0].PitrDays)
+			}
+		}
+
+		// The generated client doesn't model these yet
+		request.AdditionalProperties = map[string]interface{}{
+			"pitr_days": pitrDays,
+		}
+		if region := policy["copy_destination_region"].(string); region != "" {
+			request.AdditionalProperties["destination_region"] = region
+		}
+	} else {
+		// The block was removed, so go back to the platform's default policy
+		defaultPolicy, err := defaultBackupRetentionPolicy(ctx, meta, envId)
+		if err != nil {
+			return diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Error fetching the default backup retention policy",
+					Detail:   err.Error(),
+				},
+			}
+		}
+		request = defaultPolicy
+	}
+
+	_, err := client.BackupRetentionPoliciesAPI.
+		CreateBackupRetentionPolicy(ctx, envId).
+		CreateBackupRetentionPolicyRequest(request).
 		Execute()
 	if err != nil {
 		return diag.Diagnostics{
@@ -315,17 +403,21 @@ func createBackupRetentionPolicy(ctx context.Context, d *schema.ResourceData, me
 	return nil
 }
 
+// readBackupRetentionPolicy refreshes the policy of environments that manage
+// it. Others, including imported environments, don't track a policy so that
+// Terraform never plans to reset one it didn't set.
 func readBackupRetentionPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if configuredBackupRetentionPolicy(d) == nil {
+		return nil
+	}
+
 	met := meta.(*providerMetadata)
-	client := met.Client
 	ctx = met.APIContext(ctx)
 	envId := int32(d.Get("env_id").(int))
 
 	log.Printf("Getting backup retention policy for environment with ID: %d\n", envId)
 
-	resp, _, err := client.BackupRetentionPoliciesAPI.
-		ListBackupRetentionPoliciesForAccount(ctx, envId).
-		Execute()
+	policies, err := listBackupRetentionPolicies(ctx, meta, envId)
 	if err != nil {
 		return diag.Diagnostics{
 			diag.Diagnostic{
@@ -336,22 +428,82 @@ func readBackupRetentionPolicy(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
-	policies := resp.Embedded.BackupRetentionPolicies
 	if len(policies) == 0 {
 		// No results, the environment's policy must have been deleted
 		return nil
 	}
 
-	policy := policies[0]
-	policyData := make(map[string]interface{})
+	// The current policy is the newest one
+	current := flattenBackupRetentionPolicy(&policies[0])
 
-	policyData["daily"] = int(policy.Daily)
-	policyData["monthly"] = int(policy.Monthly)
-	policyData["yearly"] = int(policy.Yearly)
-	policyData["make_copy"] = policy.MakeCopy
-	policyData["keep_final"] = policy.KeepFinal
+	// The API may not return the copy region, keep the configured one
+	if configured := configuredBackupRetentionPolicy(d); current["copy_destination_region"] == "" && configured != nil {
+		current["copy_destination_region"] = configured["copy_destination_region"]
+	}
 
-	_ = d.Set("backup_retention_policy", []map[string]interface{}{policyData})
+	_ = d.Set("backup_retention_policy", []map[string]interface{}{current})
 
 	return nil
 }
+
+func flattenBackupRetentionPolicy(policy *aptibleapi.BackupRetentionPolicy) map[string]interface{} {
+	region, _ := policy.AdditionalProperties["destination_region"].(string)
+	return map[string]interface{}{
+		"daily":                   int(policy.Daily),
+		"monthly":                 int(policy.Monthly),
+		"yearly":                  int(policy.Yearly),
+		"make_copy":               policy.MakeCopy,
+		"keep_final":              policy.KeepFinal,
+		"pitr_days":               int(policy.PitrDays),
+		"copy_destination_region": region,
+	}
+}
+
+// defaultBackupRetentionPolicies are the platform's default policies, by the
+// type of environment they apply to
+var defaultBackupRetentionPolicies = map[string]aptibleapi.CreateBackupRetentionPolicyRequest{
+	"production": {
+		Daily:     aptibleapi.PtrInt32(30),
+		Monthly:   aptibleapi.PtrInt32(12),
+		Yearly:    aptibleapi.PtrInt32(6),
+		MakeCopy:  aptibleapi.PtrBool(true),
+		KeepFinal: aptibleapi.PtrBool(true),
+	},
+	"development": {
+		Daily:     aptibleapi.PtrInt32(1),
+		Monthly:   aptibleapi.PtrInt32(0),
+		Yearly:    aptibleapi.PtrInt32(0),
+		MakeCopy:  aptibleapi.PtrBool(false),
+		KeepFinal: aptibleapi.PtrBool(false),
+	},
+}
+
+// defaultBackupRetentionPolicy returns the platform's default policy for the
+// environment, based on the environment's type as reported by the API
+func defaultBackupRetentionPolicy(ctx context.Context, meta interface{}, envID int32) (aptibleapi.CreateBackupRetentionPolicyRequest, error) {
+	client := meta.(*providerMetadata).Client
+	account, _, err := client.AccountsAPI.GetAccount(ctx, envID).Execute()
+	if err != nil {
+		return aptibleapi.CreateBackupRetentionPolicyRequest{}, err
+	}
+	policy, ok := defaultBackupRetentionPolicies[account.Type]
+	if !ok {
+		return aptibleapi.CreateBackupRetentionPolicyRequest{}, fmt.Errorf("environment %d has unknown type %q", envID, account.Type)
+	}
+	// The default policy doesn't keep point-in-time recovery
+	policy.AdditionalProperties = map[string]interface{}{"pitr_days": 0}
+	return policy, nil
+}
+
+// listBackupRetentionPolicies returns every policy the environment has had,
+// newest first, following pagination
+func listBackupRetentionPolicies(ctx context.Context, meta interface{}, envID int32) ([]aptibleapi.BackupRetentionPolicy, error) {
+	client := meta.(*providerMetadata).Client
+	return listAllPages(func(page int32) ([]aptibleapi.BackupRetentionPolicy, int32, int32, error) {
+		resp, _, err := client.BackupRetentionPoliciesAPI.ListBackupRetentionPoliciesForAccount(ctx, envID).Page(page).Execute()
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		return resp.Embedded.BackupRetentionPolicies, resp.PerPage, resp.TotalCount, nil
+	})
+}
@@ -60,7 +60,27 @@ func TestAccResourceEnvironment_validation(t *testing.T) {
 				}
 			}
 			`, acctest.RandString(10), testOrganizationId, testStackId),
-		ExpectError: regexp.MustCompile("(?i)multiple backup_retention_policy"),
+		ExpectError: regexp.MustCompile("(?i)too many backup_retention_policy blocks"),
+	})
+
+	testSteps = append(testSteps, resource.TestStep{
+		Config: fmt.Sprintf(`
+			resource "aptible_environment" "test" {
+				handle = "%s"
+				org_id = "%s"
+				stack_id = "%v"
+
+				backup_retention_policy {
+					daily = 3
+					monthly = 2
+					yearly = 1
+					make_copy = false
+					keep_final = false
+					copy_destination_region = "us-west-2"
+				}
+			}
+			`, acctest.RandString(10), testOrganizationId, testStackId),
+		ExpectError: regexp.MustCompile("copy_destination_region can only be set when make_copy is true"),
 	})
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -110,11 +130,8 @@ func TestAccResourceEnvironment_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("aptible_environment.test", "handle", rHandle),
 					resource.TestCheckResourceAttr("aptible_environment.test", "org_id", testOrganizationId),
 					resource.TestCheckResourceAttr("aptible_environment.test", "stack_id", strconv.Itoa(testStackId)),
-					resource.TestCheckResourceAttrSet("aptible_environment.test", "backup_retention_policy.0.daily"),
-					resource.TestCheckResourceAttrSet("aptible_environment.test", "backup_retention_policy.0.monthly"),
-					resource.TestCheckResourceAttrSet("aptible_environment.test", "backup_retention_policy.0.yearly"),
-					resource.TestCheckResourceAttrSet("aptible_environment.test", "backup_retention_policy.0.make_copy"),
-					resource.TestCheckResourceAttrSet("aptible_environment.test", "backup_retention_policy.0.keep_final"),
+					// The default policy isn't tracked without the block
+					resource.TestCheckResourceAttr("aptible_environment.test", "backup_retention_policy.#", "0"),
 				),
 			}, {
 				Config:             testAccAptibleEnvironment(rHandle),
@@ -144,11 +161,8 @@ func TestAccResourceEnvironment_no_org(t *testing.T) {
 					resource.TestCheckResourceAttr("aptible_environment.test", "handle", rHandle),
 					resource.TestCheckResourceAttr("aptible_environment.test", "org_id", testOrganizationId),
 					resource.TestCheckResourceAttr("aptible_environment.test", "stack_id", strconv.Itoa(testStackId)),
-					resource.TestCheckResourceAttrSet("aptible_environment.test", "backup_retention_policy.0.daily"),
-					resource.TestCheckResourceAttrSet("aptible_environment.test", "backup_retention_policy.0.monthly"),
-					resource.TestCheckResourceAttrSet("aptible_environment.test", "backup_retention_policy.0.yearly"),
-					resource.TestCheckResourceAttrSet("aptible_environment.test", "backup_retention_policy.0.make_copy"),
-					resource.TestCheckResourceAttrSet("aptible_environment.test", "backup_retention_policy.0.keep_final"),
+					// The default policy isn't tracked without the block
+					resource.TestCheckResourceAttr("aptible_environment.test", "backup_retention_policy.#", "0"),
 				),
 			}, {
 				Config:             testAccAptibleEnvironmentWithoutOrg(rHandle),
@@ -189,10 +203,12 @@ func TestAccResourceEnvironment_backup_policy(t *testing.T) {
 				PlanOnly:           true,
 				ExpectNonEmptyPlan: false,
 			}, {
+				// Imported environments don't track their policy until the
+				// block is configured
 				ResourceName:            "aptible_environment.test",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"org_id", "stack_id"},
+				ImportStateVerifyIgnore: []string{"org_id", "stack_id", "backup_retention_policy"},
 			},
 		},
 	})
@@ -213,11 +229,8 @@ func TestAccResourceEnvironment_update(t *testing.T) {
 					resource.TestCheckResourceAttr("aptible_environment.test", "handle", rHandle),
 					resource.TestCheckResourceAttr("aptible_environment.test", "org_id", testOrganizationId),
 					resource.TestCheckResourceAttr("aptible_environment.test", "stack_id", strconv.Itoa(testStackId)),
-					resource.TestCheckResourceAttrSet("aptible_environment.test", "backup_retention_policy.0.daily"),
-					resource.TestCheckResourceAttrSet("aptible_environment.test", "backup_retention_policy.0.monthly"),
-					resource.TestCheckResourceAttrSet("aptible_environment.test", "backup_retention_policy.0.yearly"),
-					resource.TestCheckResourceAttrSet("aptible_environment.test", "backup_retention_policy.0.make_copy"),
-					resource.TestCheckResourceAttrSet("aptible_environment.test", "backup_retention_policy.0.keep_final"),
+					// The default policy isn't tracked without the block
+					resource.TestCheckResourceAttr("aptible_environment.test", "backup_retention_policy.#", "0"),
 				),
 			}, {
 				Config:             testAccAptibleEnvironment(rHandle),
@@ -248,6 +261,54 @@ func TestAccResourceEnvironment_update(t *testing.T) {
 	})
 }
 
+func TestAccResourceEnvironment_resetBackupPolicy(t *testing.T) {
+	rHandle := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckEnvironmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAptibleEnvironmentWithPitr(rHandle),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aptible_environment.test", "backup_retention_policy.0.daily", "3"),
+					resource.TestCheckResourceAttr("aptible_environment.test", "backup_retention_policy.0.pitr_days", "7"),
+					resource.TestCheckResourceAttr("aptible_environment.test", "backup_retention_policy.0.copy_destination_region", "us-west-2"),
+				),
+			}, {
+				Config:             testAccAptibleEnvironmentWithPitr(rHandle),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			}, {
+				// Leaving pitr_days out keeps point-in-time recovery
+				Config: testAccAptibleEnvironmentWithBackupPolicy(rHandle),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aptible_environment.test", "backup_retention_policy.0.pitr_days", "7"),
+				),
+			}, {
+				// Removing the block resets the policy to the default
+				Config: testAccAptibleEnvironmentWithPolicyDataSource(rHandle),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aptible_environment.test", "backup_retention_policy.#", "0"),
+					resource.TestCheckResourceAttr("data.aptible_backup_retention_policy.test", "pitr_days", "0"),
+					func(s *terraform.State) error {
+						daily := s.RootModule().Resources["data.aptible_backup_retention_policy.test"].Primary.Attributes["daily"]
+						if daily == "3" {
+							return fmt.Errorf("backup retention policy was not reset")
+						}
+						return nil
+					},
+				),
+			}, {
+				Config:             testAccAptibleEnvironmentWithPolicyDataSource(rHandle),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
 func testAccAptibleEnvironment(handle string) string {
 	return fmt.Sprintf(`
 	resource "aptible_environment" "test" {
@@ -284,3 +345,39 @@ func testAccAptibleEnvironmentWithBackupPolicy(handle string) string {
 	}
 	`, handle, testOrganizationId, testStackId)
 }
+
+func testAccAptibleEnvironmentWithPitr(handle string) string {
+	return fmt.Sprintf(`
+	resource "aptible_environment" "test" {
+		handle = "%s"
+		org_id = "%s"
+		stack_id = "%v"
+
+		backup_retention_policy {
+			daily = 3
+			monthly = 2
+			yearly = 1
+			make_copy = true
+			keep_final = false
+			pitr_days = 7
+			copy_destination_region = "us-west-2"
+		}
+	}
+	`, handle, testOrganizationId, testStackId)
+}
+
+func testAccAptibleEnvironmentWithPolicyDataSource(handle string) string {
+	return fmt.Sprintf(`
+	resource "aptible_environment" "test" {
+		handle = "%s"
+		org_id = "%s"
+		stack_id = "%v"
+	}
+
+	# Read after the environment's changes are applied
+	data "aptible_backup_retention_policy" "test" {
+		env_id     = aptible_environment.test.env_id
+		depends_on = [aptible_environment.test]
+	}
+	`, handle, testOrganizationId, testStackId)
+}
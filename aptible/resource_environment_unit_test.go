@@ -0,0 +1,130 @@
+package aptible
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDefaultBackupRetentionPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		envType   string
+		wantDaily int32
+		wantCopy  bool
+		wantErr   string
+	}{
+		{name: "production", envType: "production", wantDaily: 30, wantCopy: true},
+		{name: "development", envType: "development", wantDaily: 1, wantCopy: false},
+		{name: "unknown type", envType: "staging", wantErr: `environment 1 has unknown type "staging"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/accounts/1" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(aptibleapi.Account{Id: 1, Type: tt.envType, Handle: "example"})
+			}))
+			defer server.Close()
+
+			meta := testProviderMetadata(server)
+			got, err := defaultBackupRetentionPolicy(meta.APIContext(context.Background()), meta, 1)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("defaultBackupRetentionPolicy() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.GetDaily() != tt.wantDaily || got.GetMakeCopy() != tt.wantCopy {
+				t.Errorf("defaultBackupRetentionPolicy() = %+v, want daily %d and make_copy %t", got, tt.wantDaily, tt.wantCopy)
+			}
+			if got.AdditionalProperties["pitr_days"] != 0 {
+				t.Errorf("default pitr_days = %v, want 0", got.AdditionalProperties["pitr_days"])
+			}
+		})
+	}
+}
+
+func TestResourceEnvironmentBackupRetentionPolicyDiff(t *testing.T) {
+	managedState := map[string]string{
+		"id":                                   "1",
+		"env_id":                               "1",
+		"handle":                               "example",
+		"stack_id":                             "2",
+		"org_id":                               "00000000-0000-0000-0000-000000000000",
+		"backup_retention_policy.#":            "1",
+		"backup_retention_policy.0.daily":      "3",
+		"backup_retention_policy.0.monthly":    "2",
+		"backup_retention_policy.0.yearly":     "1",
+		"backup_retention_policy.0.make_copy":  "true",
+		"backup_retention_policy.0.keep_final": "false",
+		"backup_retention_policy.0.pitr_days":  "7",
+		"backup_retention_policy.0.copy_destination_region": "us-west-2",
+	}
+	policy := map[string]interface{}{
+		"daily":      3,
+		"monthly":    2,
+		"yearly":     1,
+		"make_copy":  true,
+		"keep_final": false,
+	}
+
+	tests := []struct {
+		name      string
+		policy    map[string]interface{}
+		wantReset bool
+		wantDiff  bool
+	}{
+		{name: "resets the policy when the block is removed", wantReset: true, wantDiff: true},
+		{name: "keeps the current pitr_days and region when they're left out", policy: policy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &terraform.InstanceState{ID: "1", Attributes: map[string]string{}}
+			for k, v := range managedState {
+				state.Attributes[k] = v
+			}
+			raw := map[string]interface{}{
+				"handle":   "example",
+				"stack_id": 2,
+				"org_id":   "00000000-0000-0000-0000-000000000000",
+			}
+			if tt.policy != nil {
+				raw["backup_retention_policy"] = []interface{}{tt.policy}
+			}
+
+			diff, err := resourceEnvironment().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// A nil diff means nothing is planned
+			if (diff != nil && !diff.Empty()) != tt.wantDiff {
+				t.Fatalf("planned changes = %v, want changes %t", diff, tt.wantDiff)
+			}
+			reset := false
+			if diff != nil {
+				attr, ok := diff.GetAttribute("backup_retention_policy.#")
+				reset = ok && attr.New == "0"
+			}
+			if reset != tt.wantReset {
+				t.Fatalf("planned reset = %t, want %t (diff: %v)", reset, tt.wantReset, diff)
+			}
+		})
+	}
+}
@@ -1,21 +1,25 @@
 package aptible
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"net/http"
 	"strconv"
 
-	"github.com/aptible/go-deploy/aptible"
-	"github.com/go-openapi/strfmt"
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceLogDrain() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceLogDrainCreate,
-		Read:   resourceLogDrainRead,
-		Delete: resourceLogDrainDelete,
+		CreateContext: resourceLogDrainCreate,
+		ReadContext:   resourceLogDrainRead,
+		UpdateContext: resourceLogDrainUpdate,
+		DeleteContext: resourceLogDrainDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceLogDrainImport,
+			StateContext: resourceLogDrainImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -82,25 +86,21 @@ func resourceLogDrain() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  true,
-				ForceNew: true,
 			},
 			"drain_databases": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  true,
-				ForceNew: true,
 			},
 			"drain_ephemeral_sessions": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  true,
-				ForceNew: true,
 			},
 			"drain_proxies": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  false,
-				ForceNew: true,
 			},
 			// aliases
 			"token": {
@@ -126,118 +126,219 @@ func resourceLogDrain() *schema.Resource {
 	}
 }
 
-func resourceLogDrainCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*providerMetadata).LegacyClient
+func resourceLogDrainCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	client := m.Client
+	ctx = m.APIContext(ctx)
+	diags := diag.Diagnostics{}
+
 	handle := d.Get("handle").(string)
-	accountID := int64(d.Get("env_id").(int))
+	accountID := int32(d.Get("env_id").(int))
 	drainType := d.Get("drain_type").(string)
-	data := &aptible.LogDrainCreateAttrs{
-		DrainType:              &drainType,
-		URL:                    strfmt.URI(d.Get("url").(string)),
-		LoggingToken:           d.Get("logging_token").(string),
-		DrainPort:              int64(d.Get("drain_port").(int)),
-		DrainPassword:          d.Get("drain_password").(string),
-		DrainHost:              strfmt.URI(d.Get("drain_host").(string)),
-		DrainUsername:          d.Get("drain_username").(string),
-		DatabaseID:             int64(d.Get("database_id").(int)),
-		DrainProxies:           d.Get("drain_proxies").(bool),
-		DrainEphemeralSessions: d.Get("drain_ephemeral_sessions").(bool),
-		DrainDatabases:         d.Get("drain_databases").(bool),
-		DrainApps:              d.Get("drain_apps").(bool),
-	}
+	loggingToken := d.Get("logging_token").(string)
+	drainUsername := d.Get("drain_username").(string)
 
 	// alias support
-	if drainType == "elasticsearch_database" && data.LoggingToken == "" {
-		data.LoggingToken = d.Get("pipeline").(string)
+	if drainType == "elasticsearch_database" && loggingToken == "" {
+		loggingToken = d.Get("pipeline").(string)
 	}
 
 	if drainType == "datadog" || drainType == "logdna" {
-		if data.DrainUsername == "" {
-			data.DrainUsername = d.Get("token").(string)
+		if drainUsername == "" {
+			drainUsername = d.Get("token").(string)
 		}
 
-		if data.LoggingToken == "" {
-			data.LoggingToken = d.Get("tags").(string)
+		if loggingToken == "" {
+			loggingToken = d.Get("tags").(string)
 		}
 	}
 
-	logDrain, err := client.CreateLogDrain(handle, accountID, data)
+	create := aptibleapi.NewCreateLogDrainRequest(handle, drainType)
+	create.SetDrainApps(d.Get("drain_apps").(bool))
+	create.SetDrainDatabases(d.Get("drain_databases").(bool))
+	create.SetDrainEphemeralSessions(d.Get("drain_ephemeral_sessions").(bool))
+	create.SetDrainProxies(d.Get("drain_proxies").(bool))
+	if v := d.Get("url").(string); v != "" {
+		create.SetUrl(v)
+	}
+	if v := d.Get("drain_host").(string); v != "" {
+		create.SetDrainHost(v)
+	}
+	if v := int32(d.Get("drain_port").(int)); v != 0 {
+		create.SetDrainPort(v)
+	}
+	if v := d.Get("drain_password").(string); v != "" {
+		create.SetDrainPassword(v)
+	}
+	if v := int32(d.Get("database_id").(int)); v != 0 {
+		create.SetDatabaseId(v)
+	}
+	if drainUsername != "" {
+		create.SetDrainUsername(drainUsername)
+	}
+	if loggingToken != "" {
+		create.SetLoggingToken(loggingToken)
+	}
+
+	logDrain, _, err := client.LogDrainsAPI.
+		CreateLogDrain(ctx, accountID).
+		CreateLogDrainRequest(*create).
+		Execute()
 	if err != nil {
 		log.Println("There was an error when completing the request to create the log drain.\n[ERROR] -", err)
-		return generateErrorFromClientError(err)
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error creating log drain with handle: %s", handle),
+			Detail:   err.Error(),
+		})
+	}
+
+	d.SetId(strconv.Itoa(int(logDrain.Id)))
+	_ = d.Set("log_drain_id", logDrain.Id)
+
+	op, _, err := client.OperationsAPI.
+		CreateOperationForLogDrain(ctx, logDrain.Id).
+		CreateOperationRequest(*aptibleapi.NewCreateOperationRequest("provision")).
+		Execute()
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error creating provision operation for log drain with handle: %s", handle),
+			Detail:   err.Error(),
+		})
 	}
-	d.SetId(strconv.Itoa(int(logDrain.ID)))
-	_ = d.Set("log_drain_id", logDrain.ID)
 
-	return resourceLogDrainRead(d, meta)
+	if _, err := waitForOperation(ctx, meta, op.Id); err != nil {
+		// Do not return here so that the read method can hydrate the state
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to provision log drain with handle: %s", handle),
+			Detail:   err.Error(),
+		})
+	}
+
+	return append(diags, resourceLogDrainRead(ctx, d, meta)...)
 }
 
-func resourceLogDrainRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*providerMetadata).LegacyClient
-	logDrainID := int64(d.Get("log_drain_id").(int))
+func resourceLogDrainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	client := m.Client
+	ctx = m.APIContext(ctx)
+	logDrainID := int32(d.Get("log_drain_id").(int))
 
 	log.Println("Getting log drain with ID: " + strconv.Itoa(int(logDrainID)))
 
-	logDrain, err := client.GetLogDrain(logDrainID)
-	if err != nil {
-		log.Println(err)
-		return generateErrorFromClientError(err)
-	}
-	if logDrain.Deleted {
+	logDrain, resp, err := client.LogDrainsAPI.GetLogDrain(ctx, logDrainID).Execute()
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
 		d.SetId("")
+		log.Printf("Log drain with ID: %d was deleted outside of Terraform. Removing it from Terraform state.", logDrainID)
 		return nil
 	}
-	_ = d.Set("log_drain_id", int(logDrain.ID))
+	if err != nil {
+		log.Println(err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error getting log drain with ID: %d", logDrainID),
+			Detail:   err.Error(),
+		}}
+	}
+
+	_ = d.Set("log_drain_id", int(logDrain.Id))
 	_ = d.Set("handle", logDrain.Handle)
 	_ = d.Set("drain_type", logDrain.DrainType)
-	_ = d.Set("url", logDrain.URL)
-	_ = d.Set("logging_token", logDrain.LoggingToken)
+	_ = d.Set("url", logDrain.GetUrl())
+	_ = d.Set("logging_token", logDrain.GetLoggingToken())
 	_ = d.Set("drain_port", logDrain.DrainPort)
-	_ = d.Set("drain_username", logDrain.DrainUsername)
-	_ = d.Set("drain_password", logDrain.DrainPassword)
+	_ = d.Set("drain_username", logDrain.GetDrainUsername())
+	_ = d.Set("drain_password", logDrain.GetDrainPassword())
 	_ = d.Set("drain_host", logDrain.DrainHost)
 	_ = d.Set("drain_proxies", logDrain.DrainProxies)
 	_ = d.Set("drain_ephemeral_sessions", logDrain.DrainEphemeralSessions)
 	_ = d.Set("drain_databases", logDrain.DrainDatabases)
 	_ = d.Set("drain_apps", logDrain.DrainApps)
-	_ = d.Set("env_id", logDrain.AccountID)
-	_ = d.Set("database_id", logDrain.DatabaseID)
+	if logDrain.Links != nil {
+		_ = d.Set("env_id", ExtractIdFromLink(logDrain.Links.Account.GetHref()))
+		// This will be unset for syslog and https drains
+		_ = d.Set("database_id", ExtractIdFromLink(logDrain.Links.Database.GetHref()))
+	}
 
 	// alias support
 	if logDrain.DrainType == "elasticsearch_database" {
-		_ = d.Set("pipeline", logDrain.LoggingToken)
+		_ = d.Set("pipeline", logDrain.GetLoggingToken())
 	}
 
 	if logDrain.DrainType == "datadog" || logDrain.DrainType == "logdna" {
-		_ = d.Set("token", logDrain.DrainUsername)
-		_ = d.Set("tags", logDrain.LoggingToken)
+		_ = d.Set("token", logDrain.GetDrainUsername())
+		_ = d.Set("tags", logDrain.GetLoggingToken())
 	}
 
 	return nil
 }
 
-func resourceLogDrainDelete(d *schema.ResourceData, meta interface{}) error {
-	readErr := resourceLogDrainRead(d, meta)
-	if readErr == nil {
-		logDrainID := int64(d.Get("log_drain_id").(int))
-		client := meta.(*providerMetadata).LegacyClient
-		deleted, err := client.DeleteLogDrain(logDrainID)
-		if deleted {
-			d.SetId("")
-			return nil
-		}
+// Only the sources a drain collects logs from can be changed through the API.
+// Changing anything else (including credentials) replaces the drain.
+func resourceLogDrainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	client := m.Client
+	ctx = m.APIContext(ctx)
+	diags := diag.Diagnostics{}
+	logDrainID := int32(d.Get("log_drain_id").(int))
+
+	if d.HasChanges("drain_apps", "drain_databases", "drain_ephemeral_sessions", "drain_proxies") {
+		update := aptibleapi.NewUpdateLogDrainRequest()
+		update.SetDrainApps(d.Get("drain_apps").(bool))
+		update.SetDrainDatabases(d.Get("drain_databases").(bool))
+		update.SetDrainEphemeralSessions(d.Get("drain_ephemeral_sessions").(bool))
+		update.SetDrainProxies(d.Get("drain_proxies").(bool))
+
+		_, err := client.LogDrainsAPI.
+			PatchLogDrain(ctx, logDrainID).
+			UpdateLogDrainRequest(*update).
+			Execute()
 		if err != nil {
-			log.Println("There was an error when completing the request to destroy the log drain.\n[ERROR] -", err)
-			return generateErrorFromClientError(err)
+			// Do not return here so that the read method can hydrate the state
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("There was an error when trying to update log drain %d", logDrainID),
+				Detail:   err.Error(),
+			})
 		}
 	}
+
+	return append(diags, resourceLogDrainRead(ctx, d, meta)...)
+}
+
+func resourceLogDrainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	logDrainID := int32(d.Get("log_drain_id").(int))
+
+	op, resp, err := m.Client.OperationsAPI.
+		CreateOperationForLogDrain(m.APIContext(ctx), logDrainID).
+		CreateOperationRequest(*aptibleapi.NewCreateOperationRequest("deprovision")).
+		Execute()
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		d.SetId("")
+		return nil
+	}
+	if err == nil {
+		_, err = waitForOperation(ctx, meta, op.Id)
+	}
+	if err != nil {
+		log.Println("There was an error when completing the request to destroy the log drain.\n[ERROR] -", err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to deprovision log drain %d", logDrainID),
+			Detail:   err.Error(),
+		}}
+	}
+
 	d.SetId("")
 	return nil
 }
 
-func resourceLogDrainImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceLogDrainImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	logDrainID, _ := strconv.Atoi(d.Id())
 	_ = d.Set("log_drain_id", logDrainID)
-	err := resourceLogDrainRead(d, meta)
-	return []*schema.ResourceData{d}, err
+	diags := resourceLogDrainRead(ctx, d, meta)
+	return []*schema.ResourceData{d}, diagnosticsToError(diags)
 }
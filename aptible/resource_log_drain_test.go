@@ -294,6 +294,43 @@ func TestAccResourceLogDrain_solarwinds(t *testing.T) {
 	})
 }
 
+func TestAccResourceLogDrain_updateSources(t *testing.T) {
+	rHandle := acctest.RandString(10)
+	var logDrainID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLogDrainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAptibleLogDrainSources(rHandle, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aptible_log_drain.test", "drain_apps", "true"),
+					resource.TestCheckResourceAttr("aptible_log_drain.test", "drain_proxies", "false"),
+					func(s *terraform.State) error {
+						logDrainID = s.RootModule().Resources["aptible_log_drain.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccAptibleLogDrainSources(rHandle, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aptible_log_drain.test", "drain_apps", "false"),
+					resource.TestCheckResourceAttr("aptible_log_drain.test", "drain_proxies", "true"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources["aptible_log_drain.test"].Primary.ID; id != logDrainID {
+							return fmt.Errorf("log drain was replaced (%s -> %s), expected an in-place update", logDrainID, id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckLogDrainDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*providerMetadata).LegacyClient
 	for _, rs := range s.RootModule().Resources {
@@ -467,3 +504,22 @@ func testAccAptibleLogDrainSolarwinds(handle string) string {
 	}
 `, handle, testOrganizationId, testStackId, handle)
 }
+
+func testAccAptibleLogDrainSources(handle string, drainApps bool) string {
+	return fmt.Sprintf(`
+	resource "aptible_environment" "test" {
+		handle = "%s"
+		org_id = "%s"
+		stack_id = "%v"
+	}
+
+	resource "aptible_log_drain" "test" {
+		env_id = aptible_environment.test.env_id
+		handle = "%v"
+		drain_type = "https_post"
+		url = "https://test.aptible.com"
+		drain_apps = %t
+		drain_proxies = %t
+	}
+`, handle, testOrganizationId, testStackId, handle, drainApps, !drainApps)
+}
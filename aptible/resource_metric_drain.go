@@ -4,10 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"strconv"
 
-	"github.com/aptible/go-deploy/aptible"
-	"github.com/go-openapi/strfmt"
+	"github.com/aptible/aptible-api-go/aptibleapi"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,6 +18,7 @@ func resourceMetricDrain() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceMetricDrainCreate,
 		ReadContext:   resourceMetricDrainRead,
+		UpdateContext: resourceMetricDrainUpdate,
 		DeleteContext: resourceMetricDrainDelete,
 		CustomizeDiff: resourceMetricDrainValidate,
 		Importer: &schema.ResourceImporter{
@@ -59,12 +60,10 @@ func resourceMetricDrain() *schema.Resource {
 			"username": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 			},
 			"password": {
 				Type:      schema.TypeString,
 				Optional:  true,
-				ForceNew:  true,
 				Sensitive: true,
 			},
 			"database": {
@@ -85,7 +84,6 @@ func resourceMetricDrain() *schema.Resource {
 			"api_key": {
 				Type:      schema.TypeString,
 				Optional:  true,
-				ForceNew:  true,
 				Sensitive: true,
 			},
 			"series_url": {
@@ -143,87 +141,237 @@ func resourceMetricDrainValidate(_ context.Context, diff *schema.ResourceDiff, _
 	return err
 }
 
+// metricDrainConfiguration builds the drain_configuration sent to the API
+func metricDrainConfiguration(d *schema.ResourceData) *aptibleapi.CreateMetricDrainRequestDrainConfiguration {
+	config := aptibleapi.NewCreateMetricDrainRequestDrainConfiguration()
+	if v := d.Get("url").(string); v != "" {
+		config.SetAddress(v)
+	}
+	if v := d.Get("username").(string); v != "" {
+		config.SetUsername(v)
+	}
+	if v := d.Get("password").(string); v != "" {
+		config.SetPassword(v)
+	}
+	if v := d.Get("database").(string); v != "" {
+		config.SetDatabase(v)
+	}
+	if v := d.Get("api_key").(string); v != "" {
+		// influxdb2 drains authenticate with the api_key as a token
+		config.SetApiKey(v)
+		config.SetAuthToken(v)
+	}
+	if v := d.Get("series_url").(string); v != "" {
+		config.SetSeriesUrl(v)
+	}
+	if v := d.Get("bucket").(string); v != "" {
+		config.SetBucket(v)
+	}
+	if v := d.Get("organization").(string); v != "" {
+		config.SetOrg(v)
+	}
+	return config
+}
+
 func resourceMetricDrainCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*providerMetadata).LegacyClient
+	m := meta.(*providerMetadata)
+	client := m.Client
+	ctx = m.APIContext(ctx)
+	diags := diag.Diagnostics{}
+
 	handle := d.Get("handle").(string)
-	accountID := int64(d.Get("env_id").(int))
-	data := &aptible.MetricDrainCreateAttrs{
-		DrainType:  d.Get("drain_type").(string),
-		DatabaseID: int64(d.Get("database_id").(int)),
-		URL:        strfmt.URI(d.Get("url").(string)),
-		Username:   d.Get("username").(string),
-		Password:   d.Get("password").(string),
-		Database:   d.Get("database").(string),
-		APIKey:     d.Get("api_key").(string),
-		AuthToken:  d.Get("api_key").(string),
-		Bucket:     d.Get("bucket").(string),
-		Org:        d.Get("organization").(string),
-		SeriesURL:  strfmt.URI(d.Get("series_url").(string)),
-	}
-
-	metricDrain, err := client.CreateMetricDrain(handle, accountID, data)
+	accountID := int32(d.Get("env_id").(int))
+	drainType := d.Get("drain_type").(string)
+
+	create := aptibleapi.NewCreateMetricDrainRequest(handle, drainType)
+	if v := int32(d.Get("database_id").(int)); v != 0 {
+		create.SetDatabaseId(v)
+	}
+	// influxdb_database drains cannot have a DrainConfiguration
+	if drainType != "influxdb_database" {
+		create.SetDrainConfiguration(*metricDrainConfiguration(d))
+	}
+
+	metricDrain, _, err := client.MetricDrainsAPI.
+		CreateMetricDrain(ctx, accountID).
+		CreateMetricDrainRequest(*create).
+		Execute()
 	if err != nil {
 		log.Println("There was an error when completing the request to create the metric drain.\n[ERROR] -", err)
-		return generateDiagnosticsFromClientError(err)
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error creating metric drain with handle: %s", handle),
+			Detail:   err.Error(),
+		})
+	}
+
+	d.SetId(strconv.Itoa(int(metricDrain.Id)))
+	_ = d.Set("metric_drain_id", metricDrain.Id)
+
+	op, _, err := client.OperationsAPI.
+		CreateOperationForMetricDrain(ctx, metricDrain.Id).
+		CreateOperationRequest(*aptibleapi.NewCreateOperationRequest("provision")).
+		Execute()
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error creating provision operation for metric drain with handle: %s", handle),
+			Detail:   err.Error(),
+		})
+	}
+
+	if _, err := waitForOperation(ctx, meta, op.Id); err != nil {
+		// Do not return here so that the read method can hydrate the state
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to provision metric drain with handle: %s", handle),
+			Detail:   err.Error(),
+		})
 	}
-	d.SetId(strconv.Itoa(int(metricDrain.ID)))
-	_ = d.Set("metric_drain_id", metricDrain.ID)
 
-	return resourceMetricDrainRead(ctx, d, meta)
+	return append(diags, resourceMetricDrainRead(ctx, d, meta)...)
 }
 
-func resourceMetricDrainRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*providerMetadata).LegacyClient
-	metricDrainID := int64(d.Get("metric_drain_id").(int))
+func resourceMetricDrainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	client := m.Client
+	ctx = m.APIContext(ctx)
+	metricDrainID := int32(d.Get("metric_drain_id").(int))
 
 	log.Println("Getting metric drain with ID: " + strconv.Itoa(int(metricDrainID)))
 
-	metricDrain, err := client.GetMetricDrain(metricDrainID)
+	metricDrain, resp, err := client.MetricDrainsAPI.GetMetricDrain(ctx, metricDrainID).Execute()
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		d.SetId("")
+		log.Printf("Metric drain with ID: %d was deleted outside of Terraform. Removing it from Terraform state.", metricDrainID)
+		return nil
+	}
 	if err != nil {
 		log.Println(err)
-		return generateDiagnosticsFromClientError(err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error getting metric drain with ID: %d", metricDrainID),
+			Detail:   err.Error(),
+		}}
 	}
-	if metricDrain.Deleted {
-		d.SetId("")
-		return nil
+
+	// influxdb2 settings aren't part of the generated model
+	config := metricDrain.DrainConfiguration
+	additional := func(key string) string {
+		v, _ := config.AdditionalProperties[key].(string)
+		return v
 	}
-	_ = d.Set("metric_drain_id", int(metricDrain.ID))
-	_ = d.Set("env_id", metricDrain.AccountID)
+
+	_ = d.Set("metric_drain_id", int(metricDrain.Id))
 	_ = d.Set("handle", metricDrain.Handle)
 	_ = d.Set("drain_type", metricDrain.DrainType)
-	_ = d.Set("database_id", metricDrain.DatabaseID)
-	_ = d.Set("url", metricDrain.URL)
-	_ = d.Set("username", metricDrain.Username)
-	_ = d.Set("password", metricDrain.Password)
-	_ = d.Set("database", metricDrain.Database)
-	if metricDrain.APIKey != "" {
-		_ = d.Set("api_key", metricDrain.APIKey)
+	if metricDrain.Links != nil {
+		_ = d.Set("env_id", ExtractIdFromLink(metricDrain.Links.Account.GetHref()))
+		_ = d.Set("database_id", ExtractIdFromLink(metricDrain.Links.Database.GetHref()))
+	}
+	_ = d.Set("url", config.GetAddress())
+	_ = d.Set("username", config.GetUsername())
+	_ = d.Set("password", config.GetPassword())
+	_ = d.Set("database", config.GetDatabase())
+	if config.GetApiKey() != "" {
+		_ = d.Set("api_key", config.GetApiKey())
 	}
-	_ = d.Set("series_url", metricDrain.SeriesURL)
-	if metricDrain.AuthToken != "" {
-		_ = d.Set("api_key", metricDrain.AuthToken)
+	_ = d.Set("series_url", config.GetSeriesUrl())
+	if authToken := additional("authToken"); authToken != "" {
+		_ = d.Set("api_key", authToken)
 	}
-	_ = d.Set("bucket", metricDrain.Bucket)
-	_ = d.Set("organization", metricDrain.Org)
+	_ = d.Set("bucket", additional("bucket"))
+	_ = d.Set("organization", additional("org"))
 
 	return nil
 }
 
-func resourceMetricDrainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	readDiags := resourceMetricDrainRead(ctx, d, meta)
-	if !readDiags.HasError() {
-		metricDrainID := int64(d.Get("metric_drain_id").(int))
-		client := meta.(*providerMetadata).LegacyClient
-		deleted, err := client.DeleteMetricDrain(metricDrainID)
-		if deleted {
-			d.SetId("")
-			return nil
-		}
-		if err != nil {
-			log.Println("There was an error when completing the request to destroy the metric drain.\n[ERROR] -", err)
-			return generateDiagnosticsFromClientError(err)
+// Credentials (username, password, and api_key) are rotated in place by a
+// configure operation, which applies them to the running drain
+func resourceMetricDrainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	ctx = m.APIContext(ctx)
+	diags := diag.Diagnostics{}
+	metricDrainID := int32(d.Get("metric_drain_id").(int))
+
+	if d.HasChanges("username", "password", "api_key") {
+		settings, sensitiveSettings := metricDrainCredentials(d)
+		if err := configureMetricDrain(ctx, meta, metricDrainID, settings, sensitiveSettings); err != nil {
+			// Do not return here so that the read method can hydrate the state
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Failed to rotate the credentials of metric drain %d", metricDrainID),
+				Detail:   err.Error(),
+			})
 		}
 	}
+
+	return append(diags, resourceMetricDrainRead(ctx, d, meta)...)
+}
+
+// metricDrainCredentials returns the drain's credentials, keyed like its
+// drain_configuration. Secrets are kept apart so the API stores them
+// encrypted.
+func metricDrainCredentials(d *schema.ResourceData) (map[string]string, map[string]string) {
+	settings := map[string]string{}
+	sensitiveSettings := map[string]string{}
+	if v := d.Get("username").(string); v != "" {
+		settings["username"] = v
+	}
+	if v := d.Get("password").(string); v != "" {
+		sensitiveSettings["password"] = v
+	}
+	if v := d.Get("api_key").(string); v != "" {
+		// influxdb2 drains authenticate with the api_key as a token
+		sensitiveSettings["api_key"] = v
+		sensitiveSettings["authToken"] = v
+	}
+	return settings, sensitiveSettings
+}
+
+// configureMetricDrain applies new settings to the drain and waits for the
+// configure operation to complete
+func configureMetricDrain(ctx context.Context, meta interface{}, metricDrainID int32, settings map[string]string, sensitiveSettings map[string]string) error {
+	client := meta.(*providerMetadata).Client
+
+	payload := aptibleapi.NewCreateOperationRequest("configure")
+	payload.SetSettings(settings)
+	payload.SetSensitiveSettings(sensitiveSettings)
+	op, _, err := client.OperationsAPI.
+		CreateOperationForMetricDrain(ctx, metricDrainID).
+		CreateOperationRequest(*payload).
+		Execute()
+	if err != nil {
+		return err
+	}
+	_, err = waitForOperation(ctx, meta, op.Id)
+	return err
+}
+
+func resourceMetricDrainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMetadata)
+	metricDrainID := int32(d.Get("metric_drain_id").(int))
+
+	op, resp, err := m.Client.OperationsAPI.
+		CreateOperationForMetricDrain(m.APIContext(ctx), metricDrainID).
+		CreateOperationRequest(*aptibleapi.NewCreateOperationRequest("deprovision")).
+		Execute()
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		d.SetId("")
+		return nil
+	}
+	if err == nil {
+		_, err = waitForOperation(ctx, meta, op.Id)
+	}
+	if err != nil {
+		log.Println("There was an error when completing the request to destroy the metric drain.\n[ERROR] -", err)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to deprovision metric drain %d", metricDrainID),
+			Detail:   err.Error(),
+		}}
+	}
+
 	d.SetId("")
 	return nil
 }
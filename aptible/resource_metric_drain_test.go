@@ -349,6 +349,41 @@ func TestAccResourceMetricDrain_datadog(t *testing.T) {
 	})
 }
 
+func TestAccResourceMetricDrain_rotateCredentials(t *testing.T) {
+	rHandle := acctest.RandString(10)
+	var metricDrainID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckMetricDrainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAptibleMetricDrainDatadogWithKey(rHandle, "test_api_key"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aptible_metric_drain.test", "api_key", "test_api_key"),
+					func(s *terraform.State) error {
+						metricDrainID = s.RootModule().Resources["aptible_metric_drain.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccAptibleMetricDrainDatadogWithKey(rHandle, "rotated_api_key"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aptible_metric_drain.test", "api_key", "rotated_api_key"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources["aptible_metric_drain.test"].Primary.ID; id != metricDrainID {
+							return fmt.Errorf("metric drain was replaced (%s -> %s), expected an in-place update", metricDrainID, id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckMetricDrainDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*providerMetadata).LegacyClient
 	for _, rs := range s.RootModule().Resources {
@@ -434,6 +469,10 @@ func testAccAptibleMetricDrainInfluxDB2(handle string) string {
 }
 
 func testAccAptibleMetricDrainDatadog(handle string) string {
+	return testAccAptibleMetricDrainDatadogWithKey(handle, "test_api_key")
+}
+
+func testAccAptibleMetricDrainDatadogWithKey(handle string, apiKey string) string {
 	return fmt.Sprintf(`
 	resource "aptible_environment" "test" {
 		handle = "%s"
@@ -445,8 +484,8 @@ func testAccAptibleMetricDrainDatadog(handle string) string {
 			env_id = aptible_environment.test.env_id
 			handle = "%v"
 			drain_type = "datadog"
-			api_key = "test_api_key"
+			api_key = "%v"
 			series_url = "https://test.aptible.com:2022"
 	}
-	`, handle, testOrganizationId, testStackId, handle)
+	`, handle, testOrganizationId, testStackId, handle, apiKey)
 }
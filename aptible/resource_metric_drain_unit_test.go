@@ -0,0 +1,70 @@
+package aptible
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/aptible/aptible-api-go/aptibleapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestMetricDrainCredentials(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMetricDrain().Schema, map[string]interface{}{
+		"handle":     "test",
+		"drain_type": "influxdb2",
+		"api_key":    "token",
+	})
+
+	settings, sensitiveSettings := metricDrainCredentials(d)
+	if len(settings) != 0 {
+		t.Errorf("settings = %v, want none", settings)
+	}
+	want := map[string]string{"api_key": "token", "authToken": "token"}
+	if !reflect.DeepEqual(sensitiveSettings, want) {
+		t.Errorf("sensitive settings = %v, want %v", sensitiveSettings, want)
+	}
+}
+
+func TestConfigureMetricDrain(t *testing.T) {
+	useFastOperationBackoff(t)
+
+	var body aptibleapi.CreateOperationRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/metric_drains/7/operations":
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decoding operation request: %s", err)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			writeTestOperation(w, *testOperation("queued"))
+		case r.Method == http.MethodGet && r.URL.Path == "/operations/42":
+			writeTestOperation(w, *testOperation("succeeded"))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	err := configureMetricDrain(context.Background(), testProviderMetadata(server), 7,
+		map[string]string{"username": "user"},
+		map[string]string{"password": "new-password"})
+	if err != nil {
+		t.Fatalf("configureMetricDrain() error = %s", err)
+	}
+
+	if body.Type != "configure" {
+		t.Errorf("operation type = %q, want configure", body.Type)
+	}
+	if got := body.GetSettings(); !reflect.DeepEqual(got, map[string]string{"username": "user"}) {
+		t.Errorf("settings = %v", got)
+	}
+	if got := body.GetSensitiveSettings(); !reflect.DeepEqual(got, map[string]string{"password": "new-password"}) {
+		t.Errorf("sensitive settings = %v", got)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/aptible/aptible-api-go/aptibleapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -19,10 +20,15 @@ func resourceReplica() *schema.Resource {
 		CreateContext: resourceReplicaCreate, // POST
 		Read:          resourceReplicaRead,   // GET
 		UpdateContext: resourceReplicaUpdate, // PUT
-		Delete:        resourceReplicaDelete, // DELETE
+		DeleteContext: resourceReplicaDelete, // DELETE
 		Importer: &schema.ResourceImporter{
 			State: resourceReplicaImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+			Update: schema.DefaultTimeout(120 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"env_id": {
@@ -73,6 +79,13 @@ func resourceReplica() *schema.Resource {
 				Computed:  true,
 				Sensitive: true,
 			},
+			"restart_triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"enable_backups": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -127,7 +140,7 @@ func resourceReplicaCreate(ctx context.Context, d *schema.ResourceData, meta int
 		})
 	}
 
-	deleted, err := legacy.WaitForOperation(int64(op.Id))
+	deleted, err := waitForOperation(ctx, meta, op.Id)
 	if err != nil {
 		return append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -161,7 +174,7 @@ func resourceReplicaCreate(ctx context.Context, d *schema.ResourceData, meta int
 		return append(diags, diag.FromErr(resourceReplicaRead(d, meta))...)
 	}
 	operationID := (*operation).ID
-	deleted, err = legacy.WaitForOperation(operationID)
+	deleted, err = waitForOperation(ctx, meta, int32(operationID))
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -273,7 +286,6 @@ func resourceReplicaRead(d *schema.ResourceData, meta interface{}) error {
 // changes state of actual resource based on changes made in a Terraform config file
 func resourceReplicaUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*providerMetadata).Client
-	legacy := meta.(*providerMetadata).LegacyClient
 	ctx = meta.(*providerMetadata).APIContext(ctx)
 	databaseID := int32(d.Get("replica_id").(int))
 	containerSize := int32(d.Get("container_size").(int))
@@ -355,12 +367,12 @@ func resourceReplicaUpdate(ctx context.Context, d *schema.ResourceData, meta int
 			return diags
 		}
 
-		del, err := legacy.WaitForOperation(int64(op.Id))
+		del, err := waitForOperation(ctx, meta, op.Id)
 		if err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  "There was an error when trying to update the database.",
-				Detail:   generateErrorFromClientError(err).Error(),
+				Detail:   err.Error(),
 			})
 			return diags
 		}
@@ -374,6 +386,18 @@ func resourceReplicaUpdate(ctx context.Context, d *schema.ResourceData, meta int
 		}
 	}
 
+	// A restart for a resize already covers the triggers
+	if !needsOperation && d.HasChange("restart_triggers") {
+		if err := restartDatabase(ctx, meta, databaseID); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("There was an error when trying to restart the replica with handle: %s", handle),
+				Detail:   err.Error(),
+			})
+			return append(diags, diag.FromErr(resourceReplicaRead(d, meta))...)
+		}
+	}
+
 	if d.HasChange("handle") {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
@@ -393,13 +417,11 @@ func resourceReplicaUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	return diags
 }
 
-func resourceReplicaDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*providerMetadata).LegacyClient
-	replicaID := int64(d.Get("replica_id").(int))
-	err := client.DeleteReplica(replicaID)
-	if err != nil {
-		log.Println(err)
-		return generateErrorFromClientError(err)
+func resourceReplicaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	replicaID := int32(d.Get("replica_id").(int))
+
+	if diags := deprovisionDatabase(ctx, meta, replicaID); diags.HasError() {
+		return diags
 	}
 
 	d.SetId("")
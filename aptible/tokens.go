@@ -0,0 +1,152 @@
+package aptible
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tokens are refreshed this long before they expire so that a request started
+// just before expiry doesn't fail part way through an apply.
+const tokenRefreshWindow = 5 * time.Minute
+
+// tokenSource hands out the current access token for a provider instance.
+//
+// When the provider was configured with a username and password, the token is
+// re-acquired when it is about to expire or when the API rejects it, so long
+// running applies (e.g. large database provisions) don't fail with a 401.
+// Tokens supplied directly can't be refreshed and are used as-is.
+type tokenSource struct {
+	mu      sync.Mutex
+	config  *providerConfig
+	token   string
+	expires time.Time
+
+	// login re-acquires a token, overridden in tests
+	login func(authURL, username, password string) (string, error)
+}
+
+func newTokenSource(config *providerConfig, token string) *tokenSource {
+	return &tokenSource{
+		config:  config,
+		token:   token,
+		expires: tokenExpiry(token),
+		login:   loginWithUsernameAndPassword,
+	}
+}
+
+func (s *tokenSource) canRefresh() bool {
+	return s.config != nil && s.config.Username != "" && s.config.Password != ""
+}
+
+// Token returns a token that is valid for at least tokenRefreshWindow, if
+// possible. Refresh failures are logged and the current token is returned so
+// the request fails with the API's error instead of being silently dropped.
+func (s *tokenSource) Token() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.canRefresh() && !s.expires.IsZero() && time.Until(s.expires) < tokenRefreshWindow {
+		if err := s.refreshLocked(); err != nil {
+			log.Println("[WARN] Unable to refresh the Aptible access token:", err)
+		}
+	}
+
+	return s.token
+}
+
+// Refresh re-acquires the token after stale was rejected by the API. If another
+// request already replaced stale, the newer token is returned without logging
+// in again.
+func (s *tokenSource) Refresh(stale string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != stale {
+		return s.token, nil
+	}
+	if err := s.refreshLocked(); err != nil {
+		return "", err
+	}
+	return s.token, nil
+}
+
+func (s *tokenSource) refreshLocked() error {
+	log.Println("[INFO] Refreshing the Aptible access token")
+	token, err := s.login(s.config.AuthRootURL, s.config.Username, s.config.Password)
+	if err != nil {
+		return err
+	}
+	s.token = token
+	s.expires = tokenExpiry(token)
+	return nil
+}
+
+// tokenExpiry reads the exp claim of a JWT access token. A zero time is returned
+// if the token isn't a JWT or has no expiry.
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(claims.Exp, 0)
+}
+
+// refreshingTransport retries requests rejected with a 401 once, using a
+// freshly acquired token.
+type refreshingTransport struct {
+	base   http.RoundTripper
+	tokens *tokenSource
+}
+
+func (t *refreshingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || !t.tokens.canRefresh() {
+		return resp, err
+	}
+
+	// Requests with a body can only be replayed if it can be rewound
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	stale := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	token, refreshErr := t.tokens.Refresh(stale)
+	if refreshErr != nil {
+		log.Println("[WARN] Unable to refresh the Aptible access token:", refreshErr)
+		return resp, nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	retry.Header.Set("Authorization", "Bearer "+token)
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	return t.base.RoundTrip(retry)
+}
@@ -0,0 +1,163 @@
+package aptible
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testJWT(exp time.Time) string {
+	claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, exp.Unix())))
+	return "header." + claims + ".signature"
+}
+
+func TestTokenExpiry(t *testing.T) {
+	exp := time.Unix(time.Now().Add(time.Hour).Unix(), 0)
+
+	if got := tokenExpiry(testJWT(exp)); !got.Equal(exp) {
+		t.Errorf("tokenExpiry() = %v, want %v", got, exp)
+	}
+	if got := tokenExpiry("not-a-jwt"); !got.IsZero() {
+		t.Errorf("tokenExpiry() = %v, want zero time for an opaque token", got)
+	}
+	if got := tokenExpiry("a.!!!.c"); !got.IsZero() {
+		t.Errorf("tokenExpiry() = %v, want zero time for an invalid payload", got)
+	}
+}
+
+func TestTokenSourceToken(t *testing.T) {
+	credentials := &providerConfig{Username: "robot@example.com", Password: "secret"}
+	fresh := testJWT(time.Now().Add(12 * time.Hour))
+
+	tests := []struct {
+		name      string
+		config    *providerConfig
+		token     string
+		want      string
+		wantLogin bool
+	}{
+		{
+			name:      "refreshes a token that is about to expire",
+			config:    credentials,
+			token:     testJWT(time.Now().Add(time.Minute)),
+			want:      fresh,
+			wantLogin: true,
+		},
+		{
+			name:   "keeps a token that is still valid",
+			config: credentials,
+			token:  testJWT(time.Now().Add(time.Hour)),
+		},
+		{
+			name:   "keeps an expiring token without credentials",
+			config: &providerConfig{AccessToken: "token"},
+			token:  testJWT(time.Now().Add(time.Minute)),
+		},
+		{
+			name:   "keeps an opaque token",
+			config: credentials,
+			token:  "opaque",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loggedIn := false
+			s := newTokenSource(tt.config, tt.token)
+			s.login = func(_, _, _ string) (string, error) {
+				loggedIn = true
+				return fresh, nil
+			}
+
+			want := tt.want
+			if want == "" {
+				want = tt.token
+			}
+			if got := s.Token(); got != want {
+				t.Errorf("Token() = %q, want %q", got, want)
+			}
+			if loggedIn != tt.wantLogin {
+				t.Errorf("login called = %v, want %v", loggedIn, tt.wantLogin)
+			}
+		})
+	}
+}
+
+func TestRefreshingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		config     *providerConfig
+		method     string
+		body       string
+		wantStatus int
+		wantLogins int
+	}{
+		{
+			name:       "retries a rejected request with a refreshed token",
+			config:     &providerConfig{Username: "robot@example.com", Password: "secret"},
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantLogins: 1,
+		},
+		{
+			name:       "replays the request body",
+			config:     &providerConfig{Username: "robot@example.com", Password: "secret"},
+			method:     http.MethodPost,
+			body:       `{"type":"restart"}`,
+			wantStatus: http.StatusOK,
+			wantLogins: 1,
+		},
+		{
+			name:       "returns the 401 when the token can't be refreshed",
+			config:     &providerConfig{AccessToken: "stale"},
+			method:     http.MethodGet,
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logins := 0
+			tokens := newTokenSource(tt.config, "stale")
+			tokens.login = func(_, _, _ string) (string, error) {
+				logins++
+				return "fresh", nil
+			}
+			client := &http.Client{Transport: &refreshingTransport{base: http.DefaultTransport, tokens: tokens}}
+
+			var req *http.Request
+			if tt.body != "" {
+				req, _ = http.NewRequest(tt.method, server.URL, strings.NewReader(tt.body))
+			} else {
+				req, _ = http.NewRequest(tt.method, server.URL, nil)
+			}
+			req.Header.Set("Authorization", "Bearer stale")
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if logins != tt.wantLogins {
+				t.Errorf("logins = %d, want %d", logins, tt.wantLogins)
+			}
+		})
+	}
+}
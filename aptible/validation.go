@@ -2,7 +2,9 @@ package aptible
 
 import (
 	"fmt"
+	"net/netip"
 	"net/url"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -88,3 +90,58 @@ func normalizeContainerProfile(v interface{}) string {
 }
 
 var validateDiskSize = validation.IntBetween(1, 16000)
+
+// Parameter names across engines, e.g. "work_mem", "maxmemory-policy" or
+// "auto_explain.log_min_duration"
+var databaseParameterNameRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]*$`)
+
+// validateDatabaseParameters checks the names and values of a database's
+// parameters. Whether the database supports a parameter is only known once
+// it's applied.
+func validateDatabaseParameters(i interface{}, k string) (_ []string, errors []error) {
+	parameters, ok := i.(map[string]interface{})
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be map", k))
+		return
+	}
+
+	for name, v := range parameters {
+		if !databaseParameterNameRegexp.MatchString(name) {
+			errors = append(errors, fmt.Errorf("%q contains an invalid parameter name %q, names may only contain letters, digits, '_', '.' and '-'", k, name))
+			continue
+		}
+		if value, _ := v.(string); value == "" {
+			errors = append(errors, fmt.Errorf("%q parameter %q has an empty value, remove it from %q to reset it to the default", k, name, k))
+		}
+	}
+	return
+}
+
+// The most sources an endpoint's IP filtering can allow
+const maxIPFilteringEntries = 50
+
+// parseIPFilteringSource returns an IP filtering source as a CIDR so that
+// sources can be compared, e.g. "1.2.3.4" and "1.2.3.4/32". Endpoints only
+// filter IPv4 traffic.
+func parseIPFilteringSource(source string) (netip.Prefix, error) {
+	if addr, err := netip.ParseAddr(source); err == nil && addr.Is4() {
+		return netip.PrefixFrom(addr, 32), nil
+	}
+	if prefix, err := netip.ParsePrefix(source); err == nil && prefix.Addr().Is4() {
+		return prefix, nil
+	}
+	return netip.Prefix{}, fmt.Errorf("%q is not an IPv4 address or CIDR", source)
+}
+
+func validateIPFilteringSource(i interface{}, k string) (_ []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	if _, err := parseIPFilteringSource(v); err != nil {
+		errors = append(errors, fmt.Errorf("expected %q to be an IPv4 address or CIDR, got %q", k, v))
+	}
+	return
+}
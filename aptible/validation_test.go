@@ -105,3 +105,90 @@ func Test_errorsToWarnings(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateDatabaseParameters(t *testing.T) {
+	testAttr := "parameters"
+	tests := []struct {
+		name       string
+		i          interface{}
+		wantErrors []error
+	}{
+		{
+			name:       "returns an error when given a non-map",
+			i:          "max_connections",
+			wantErrors: []error{fmt.Errorf("expected type of %q to be map", testAttr)},
+		},
+		{
+			name:       "returns an error when a name is invalid",
+			i:          map[string]interface{}{"max connections": "100"},
+			wantErrors: []error{fmt.Errorf("%q contains an invalid parameter name %q, names may only contain letters, digits, '_', '.' and '-'", testAttr, "max connections")},
+		},
+		{
+			name:       "returns an error when a value is empty",
+			i:          map[string]interface{}{"work_mem": ""},
+			wantErrors: []error{fmt.Errorf("%q parameter %q has an empty value, remove it from %q to reset it to the default", testAttr, "work_mem", testAttr)},
+		},
+		{
+			name: "returns no errors when the parameters are valid",
+			i: map[string]interface{}{
+				"max_connections":               "200",
+				"maxmemory-policy":              "allkeys-lru",
+				"auto_explain.log_min_duration": "250ms",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, gotErrors := validateDatabaseParameters(tt.i, testAttr)
+			if !reflect.DeepEqual(gotErrors, tt.wantErrors) {
+				t.Errorf("validateDatabaseParameters() gotErrors = %v, want %v", gotErrors, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestValidateIPFilteringSource(t *testing.T) {
+	testAttr := "ip_filtering.0.cidr"
+	tests := []struct {
+		name       string
+		i          interface{}
+		wantErrors []error
+	}{
+		{
+			name:       "returns an error when given a non-string",
+			i:          32,
+			wantErrors: []error{fmt.Errorf("expected type of %q to be string", testAttr)},
+		},
+		{
+			name:       "returns an error when given a typo",
+			i:          "10.0.0/24",
+			wantErrors: []error{fmt.Errorf("expected %q to be an IPv4 address or CIDR, got %q", testAttr, "10.0.0/24")},
+		},
+		{
+			name:       "returns an error when the prefix is too long",
+			i:          "10.0.0.0/33",
+			wantErrors: []error{fmt.Errorf("expected %q to be an IPv4 address or CIDR, got %q", testAttr, "10.0.0.0/33")},
+		},
+		{
+			name:       "returns an error when given an IPv6 CIDR",
+			i:          "2001:db8::/32",
+			wantErrors: []error{fmt.Errorf("expected %q to be an IPv4 address or CIDR, got %q", testAttr, "2001:db8::/32")},
+		},
+		{
+			name: "returns no errors when given an IPv4 address",
+			i:    "1.1.1.1",
+		},
+		{
+			name: "returns no errors when given an IPv4 CIDR",
+			i:    "10.0.0.0/16",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, gotErrors := validateIPFilteringSource(tt.i, testAttr)
+			if !reflect.DeepEqual(gotErrors, tt.wantErrors) {
+				t.Errorf("validateIPFilteringSource() gotErrors = %v, want %v", gotErrors, tt.wantErrors)
+			}
+		})
+	}
+}